@@ -0,0 +1,234 @@
+package Graph
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"image/color"
+	"math"
+	"strings"
+)
+
+type CursorTool struct {
+	ToolBase
+	position fyne.Position
+	hovering bool
+	pinned   []float64
+	mirrored bool
+	mirrorX  float64
+}
+
+type cursorSample struct {
+	x, y   float64
+	series *Series
+}
+
+var (
+	cursorLineColor    = color.NRGBA{R: 255, G: 255, B: 255, A: 160}
+	cursorPinnedColor  = color.NRGBA{R: 255, G: 200, B: 0, A: 220}
+	cursorTooltipColor = color.NRGBA{R: 40, G: 40, B: 40, A: 220}
+)
+
+func NewCursorTool() *CursorTool {
+	t := &CursorTool{}
+	t.intents.render = true
+	t.intents.button = true
+	t.intents.hover = true
+	t.intents.tap = true
+	return t
+}
+
+func (t *CursorTool) registerButtons() {
+	if t.widget == nil {
+		return
+	}
+
+	t.widget.buttons = append(t.widget.buttons, widget.NewButton("Cursor", t.toggle))
+}
+
+func (t *CursorTool) Enable() {
+	t.ToolBase.Enable()
+}
+
+func (t *CursorTool) Disable() {
+	t.ToolBase.Disable()
+	t.hovering = false
+	t.pinned = nil
+}
+
+func (t *CursorTool) toggle() {
+	if t.active {
+		t.Disable()
+	} else {
+		t.Enable()
+	}
+	t.widget.Refresh()
+}
+
+func (t *CursorTool) onHover(position fyne.Position) {
+	t.position = position
+	t.hovering = true
+	if t.widget.linkGroup != nil {
+		x, _ := t.widget.pixelToData(position)
+		t.widget.linkGroup.cursorMoved(t.widget, x, true)
+	}
+}
+
+func (t *CursorTool) onHoverEnd() {
+	t.hovering = false
+	if t.widget.linkGroup != nil {
+		t.widget.linkGroup.cursorMoved(t.widget, 0, false)
+	}
+}
+
+func (t *CursorTool) mirror(x float64, show bool) {
+	t.mirrorX = x
+	t.mirrored = show
+}
+
+func (t *CursorTool) onTap(position fyne.Position) {
+	if !t.active {
+		return
+	}
+	if len(t.pinned) == 2 {
+		t.pinned = nil
+	}
+	x, _ := t.widget.pixelToData(position)
+	if samples := t.snap(x); len(samples) > 0 {
+		x = samples[0].x
+	}
+	t.pinned = append(t.pinned, x)
+	if t.widget.linkGroup != nil {
+		t.widget.linkGroup.cursorsPinned(t.widget, t.pinned)
+	}
+}
+
+func (t *CursorTool) snap(x float64) []cursorSample {
+	var samples []cursorSample
+	for _, series := range t.widget.series {
+		points := series.points()
+		if !series.Visible || len(points) == 0 {
+			continue
+		}
+		best, bestDistance := 0, math.Inf(1)
+		for i, point := range points {
+			if distance := math.Abs(point.X - x); distance < bestDistance {
+				best, bestDistance = i, distance
+			}
+		}
+		samples = append(samples, cursorSample{x: points[best].X, y: points[best].Y, series: series})
+	}
+	return samples
+}
+
+func (t *CursorTool) onRender(c *vgimg.Canvas) {
+	if !t.active {
+		return
+	}
+	dc := draw.New(c)
+	area := t.widget.dataArea
+
+	for i, x := range t.pinned {
+		dots := t.widget.dataToDots(x, t.widget.Plot.Y.Min)
+		if dots.X < area.Min.X || dots.X > area.Max.X {
+			continue
+		}
+		dc.StrokeLine2(draw.LineStyle{Color: cursorPinnedColor, Width: vg.Points(1), Dashes: []vg.Length{vg.Points(4), vg.Points(2)}}, dots.X, area.Min.Y, dots.X, area.Max.Y)
+		t.drawText(dc, vg.Point{X: dots.X + vg.Points(2), Y: area.Max.Y - vg.Points(12)}, string(rune('A'+i)))
+	}
+	if len(t.pinned) == 2 {
+		t.drawDelta(dc)
+	}
+
+	if !t.hovering {
+		if t.mirrored {
+			t.drawMirror(dc)
+		}
+		return
+	}
+	pointer := vg.Point{X: vg.Length(t.widget.pixelToDotsX(float64(t.position.X))), Y: vg.Length(t.widget.pixelToDotsY(float64(t.position.Y)))}
+	if !rectangleContains(area, pointer) {
+		return
+	}
+	lineStyle := draw.LineStyle{Color: cursorLineColor, Width: vg.Points(0.5)}
+	dc.StrokeLine2(lineStyle, pointer.X, area.Min.Y, pointer.X, area.Max.Y)
+	dc.StrokeLine2(lineStyle, area.Min.X, pointer.Y, area.Max.X, pointer.Y)
+
+	x, y := t.widget.pixelToData(t.position)
+	lines := []string{fmt.Sprintf("x: %.4g  y: %.4g", x, y)}
+	t.drawTooltip(dc, pointer, append(lines, t.drawSamples(dc, t.snap(x))...))
+}
+
+func (t *CursorTool) drawSamples(dc draw.Canvas, samples []cursorSample) []string {
+	var lines []string
+	for _, sample := range samples {
+		dots := t.widget.dataToDots(sample.x, t.widget.toPlotY(sample.series)(sample.y))
+		if rectangleContains(t.widget.dataArea, dots) {
+			dc.DrawGlyph(draw.GlyphStyle{Color: sample.series.Color, Radius: vg.Points(3), Shape: draw.CircleGlyph{}}, dots)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s @ %.4g", sample.series.Name, sample.series.format(sample.y), sample.x))
+	}
+	return lines
+}
+
+func (t *CursorTool) drawMirror(dc draw.Canvas) {
+	area := t.widget.dataArea
+	dots := t.widget.dataToDots(t.mirrorX, t.widget.Plot.Y.Min)
+	if dots.X < area.Min.X || dots.X > area.Max.X {
+		return
+	}
+	dc.StrokeLine2(draw.LineStyle{Color: cursorLineColor, Width: vg.Points(0.5)}, dots.X, area.Min.Y, dots.X, area.Max.Y)
+	lines := []string{fmt.Sprintf("x: %.4g", t.mirrorX)}
+	lines = append(lines, t.drawSamples(dc, t.snap(t.mirrorX))...)
+	t.drawTooltip(dc, vg.Point{X: dots.X, Y: area.Max.Y}, lines)
+}
+
+func (t *CursorTool) drawDelta(dc draw.Canvas) {
+	from, to := t.snap(t.pinned[0]), t.snap(t.pinned[1])
+	lines := []string{fmt.Sprintf("Δx: %.4g", t.pinned[1]-t.pinned[0])}
+	for i := range from {
+		if i < len(to) {
+			lines = append(lines, fmt.Sprintf("Δ%s: %s", from[i].series.Name, from[i].series.format(to[i].y-from[i].y)))
+		}
+	}
+	area := t.widget.dataArea
+	t.drawTooltip(dc, vg.Point{X: area.Min.X, Y: area.Max.Y}, lines)
+}
+
+func (t *CursorTool) drawTooltip(dc draw.Canvas, anchor vg.Point, lines []string) {
+	style := t.textStyle()
+	text := strings.Join(lines, "\n")
+	size := style.Rectangle(text).Size()
+	padding := vg.Points(3)
+	origin := vg.Point{X: anchor.X + vg.Points(8), Y: anchor.Y - vg.Points(8) - size.Y}
+	area := t.widget.dataArea
+	if origin.X+size.X+2*padding > area.Max.X {
+		origin.X = anchor.X - vg.Points(8) - size.X - 2*padding
+	}
+	if origin.Y < area.Min.Y {
+		origin.Y = anchor.Y + vg.Points(8)
+	}
+	box := vg.Rectangle{Min: origin, Max: vg.Point{X: origin.X + size.X + 2*padding, Y: origin.Y + size.Y + 2*padding}}
+	dc.SetColor(cursorTooltipColor)
+	dc.Fill(box.Path())
+	dc.FillText(style, vg.Point{X: origin.X + padding, Y: origin.Y + padding}, text)
+}
+
+func (t *CursorTool) drawText(dc draw.Canvas, position vg.Point, text string) {
+	dc.FillText(t.textStyle(), position, text)
+}
+
+func (t *CursorTool) textStyle() draw.TextStyle {
+	style := t.widget.Plot.X.Tick.Label
+	style.Color = color.White
+	style.XAlign = draw.XLeft
+	style.YAlign = draw.YBottom
+	return style
+}
+
+func rectangleContains(rectangle vg.Rectangle, point vg.Point) bool {
+	return point.X >= rectangle.Min.X && point.X <= rectangle.Max.X && point.Y >= rectangle.Min.Y && point.Y <= rectangle.Max.Y
+}
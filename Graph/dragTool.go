@@ -7,6 +7,7 @@ import (
 
 type DragTool struct {
 	ToolBase
+	dragging bool
 }
 
 func NewDragTool() *DragTool {
@@ -38,8 +39,22 @@ func (d *DragTool) onDrag(ev *fyne.DragEvent) {
 	if !d.active {
 		return
 	}
-	d.widget.Plot.X.Min -= float64(ev.Dragged.DX) * (d.widget.Plot.X.Max - d.widget.Plot.X.Min) / float64(d.widget.Size().Width)
-	d.widget.Plot.X.Max -= float64(ev.Dragged.DX) * (d.widget.Plot.X.Max - d.widget.Plot.X.Min) / float64(d.widget.Size().Width)
-	d.widget.Plot.Y.Min += float64(ev.Dragged.DY) * (d.widget.Plot.Y.Max - d.widget.Plot.Y.Min) / float64(d.widget.Size().Height)
-	d.widget.Plot.Y.Max += float64(ev.Dragged.DY) * (d.widget.Plot.Y.Max - d.widget.Plot.Y.Min) / float64(d.widget.Size().Height)
+	if !d.dragging {
+		d.dragging = true
+		d.widget.recordHistory()
+	}
+	width, height := d.widget.dataAreaPixelSize()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	dx := float64(ev.Dragged.DX) * (d.widget.Plot.X.Max - d.widget.Plot.X.Min) / width
+	dy := float64(ev.Dragged.DY) * (d.widget.Plot.Y.Max - d.widget.Plot.Y.Min) / height
+	d.widget.Plot.X.Min -= dx
+	d.widget.Plot.X.Max -= dx
+	d.widget.Plot.Y.Min += dy
+	d.widget.Plot.Y.Max += dy
+}
+
+func (d *DragTool) onDragEnd() {
+	d.dragging = false
 }
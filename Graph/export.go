@@ -0,0 +1,76 @@
+package Graph
+
+import (
+	"fmt"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgpdf"
+	"gonum.org/v1/plot/vg/vgsvg"
+	"io"
+	"strings"
+)
+
+type ExportFormat string
+
+const (
+	ExportPNG ExportFormat = "png"
+	ExportSVG ExportFormat = "svg"
+	ExportPDF ExportFormat = "pdf"
+)
+
+var ExportFormats = []ExportFormat{ExportPNG, ExportSVG, ExportPDF}
+
+type ExportOptions struct {
+	Format ExportFormat
+	Width  vg.Length
+	Height vg.Length
+	DPI    int
+}
+
+func ParseExportFormat(name string) (ExportFormat, error) {
+	format := ExportFormat(strings.ToLower(strings.TrimPrefix(name, ".")))
+	for _, supported := range ExportFormats {
+		if format == supported {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported export format %q", name)
+}
+
+func (w *Widget) DefaultExportOptions() ExportOptions {
+	scale := vg.Inch / vgimg.DefaultDPI
+	return ExportOptions{
+		Format: ExportPNG,
+		Width:  vg.Length(w.Size().Width) * scale,
+		Height: vg.Length(w.Size().Height-w.buttonContainer.Size().Height) * scale,
+		DPI:    vgimg.DefaultDPI,
+	}
+}
+
+func (w *Widget) Export(writer io.Writer, options ExportOptions) error {
+	if options.Width <= 0 || options.Height <= 0 {
+		return fmt.Errorf("invalid export size %vx%v", options.Width, options.Height)
+	}
+	if options.DPI <= 0 {
+		options.DPI = vgimg.DefaultDPI
+	}
+
+	var canvas vg.CanvasWriterTo
+	switch options.Format {
+	case ExportPNG:
+		canvas = vgimg.PngCanvas{Canvas: vgimg.NewWith(vgimg.UseWH(options.Width, options.Height), vgimg.UseDPI(options.DPI))}
+	case ExportSVG:
+		canvas = vgsvg.New(options.Width, options.Height)
+	case ExportPDF:
+		canvas = vgpdf.New(options.Width, options.Height)
+	default:
+		return fmt.Errorf("unsupported export format %q", options.Format)
+	}
+
+	dataArea, legend := w.dataArea, w.legend
+	w.draw(draw.New(canvas))
+	w.dataArea, w.legend = dataArea, legend
+	_, err := canvas.WriteTo(writer)
+	return err
+}
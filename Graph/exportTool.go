@@ -0,0 +1,106 @@
+package Graph
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot/vg"
+	"strconv"
+)
+
+type ExportTool struct {
+	ToolBase
+	fileName string
+}
+
+func NewExportTool(fileName string) *ExportTool {
+	t := &ExportTool{fileName: fileName}
+	t.intents.button = true
+	return t
+}
+
+func (t *ExportTool) registerButtons() {
+	if t.widget == nil {
+		return
+	}
+
+	t.widget.buttons = append(t.widget.buttons, widget.NewButton("Save", t.showDialog))
+}
+
+func (t *ExportTool) showDialog() {
+	window := t.widget.window()
+	if window == nil {
+		return
+	}
+	defaults := t.widget.DefaultExportOptions()
+
+	var formats []string
+	for _, format := range ExportFormats {
+		formats = append(formats, string(format))
+	}
+	formatSelect := widget.NewSelect(formats, nil)
+	formatSelect.SetSelected(string(defaults.Format))
+	widthEntry := widget.NewEntry()
+	widthEntry.SetText(strconv.FormatFloat(float64(defaults.Width/vg.Centimeter), 'f', 1, 64))
+	heightEntry := widget.NewEntry()
+	heightEntry.SetText(strconv.FormatFloat(float64(defaults.Height/vg.Centimeter), 'f', 1, 64))
+	dpiEntry := widget.NewEntry()
+	dpiEntry.SetText(strconv.Itoa(defaults.DPI))
+
+	dialog.ShowForm("Save graph", "Choose file", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Format", formatSelect),
+		widget.NewFormItem("Width (cm)", widthEntry),
+		widget.NewFormItem("Height (cm)", heightEntry),
+		widget.NewFormItem("DPI", dpiEntry),
+	}, func(ok bool) {
+		if !ok {
+			return
+		}
+		options, err := parseExportOptions(formatSelect.Selected, widthEntry.Text, heightEntry.Text, dpiEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			if err := t.widget.Export(writer, options); err != nil {
+				writer.Close()
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := writer.Close(); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+		saveDialog.SetFileName(t.fileName + "." + string(options.Format))
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + string(options.Format)}))
+		saveDialog.Show()
+	}, window)
+}
+
+func parseExportOptions(format, width, height, dpi string) (ExportOptions, error) {
+	var options ExportOptions
+	var err error
+	if options.Format, err = ParseExportFormat(format); err != nil {
+		return options, err
+	}
+	widthCentimeters, err := strconv.ParseFloat(width, 64)
+	if err != nil || widthCentimeters <= 0 {
+		return options, fmt.Errorf("invalid width %q", width)
+	}
+	heightCentimeters, err := strconv.ParseFloat(height, 64)
+	if err != nil || heightCentimeters <= 0 {
+		return options, fmt.Errorf("invalid height %q", height)
+	}
+	if options.DPI, err = strconv.Atoi(dpi); err != nil || options.DPI <= 0 {
+		return options, fmt.Errorf("invalid DPI %q", dpi)
+	}
+	options.Width = vg.Length(widthCentimeters) * vg.Centimeter
+	options.Height = vg.Length(heightCentimeters) * vg.Centimeter
+	return options, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -11,6 +12,9 @@ import (
 	"gonum.org/v1/plot/vg/vgimg"
 	"image"
 	"image/color"
+	"math"
+	"sync"
+	"time"
 )
 
 type Widget struct {
@@ -26,12 +30,39 @@ type Widget struct {
 	tools           []tool
 	buttons         []fyne.CanvasObject
 	buttonContainer *fyne.Container
+	series          []*Series
+	streams         []*StreamingSeries
+	dataArea        vg.Rectangle
+	rightAxis       *rightAxis
+	legend          []legendEntry
+	showLegend      bool
+	linkGroup       *LinkGroup
+	history         []axisRange
+	future          []axisRange
+	lastScroll      time.Time
+	streamWindow    float64
+	following       bool
+	refreshInterval time.Duration
+	lastRefresh     time.Time
+	refreshPending  bool
+	refreshMu       sync.Mutex
+	plotMu          sync.Mutex // Guards the axes, the data area and following, which renders and linked widgets change
+}
+
+const (
+	maxHistory             = 50
+	historyThrottleTimeout = 500 * time.Millisecond
+)
+
+type axisRange struct {
+	xMin, xMax, yMin, yMax float64
 }
 
 func NewGraphWidget() *Widget {
 	w := &Widget{}
 	w.ExtendBaseWidget(w)
 	w.Plot = plot.New()
+	w.showLegend = true
 
 	w.Plot.BackgroundColor = color.RGBA{R: 20, G: 20, B: 20}
 	w.Plot.X.Color = color.White
@@ -71,15 +102,138 @@ func (w *Widget) SetMaxBounds(xMin, xMax, yMin, yMax float64) *Widget {
 	return w
 }
 
+func (w *Widget) AddSeries(series ...*Series) *Widget {
+	for _, s := range series {
+		if streaming, ok := s.data.(*StreamingSeries); ok {
+			streaming.mu.Lock()
+			streaming.onAppend = w.requestRefresh
+			streaming.mu.Unlock()
+			w.streams = append(w.streams, streaming)
+		}
+	}
+	w.series = append(w.series, series...)
+	return w
+}
+
+func (w *Widget) ClearSeries() *Widget {
+	for _, streaming := range w.streams {
+		streaming.mu.Lock()
+		streaming.onAppend = nil
+		streaming.mu.Unlock()
+	}
+	w.streams = nil
+	w.series = nil
+	w.Refresh()
+	return w
+}
+
+func (w *Widget) Series() []*Series {
+	return w.series
+}
+
+func (w *Widget) AddStreamingSeries(name, unit string, series *StreamingSeries) *Widget {
+	return w.AddSeries(NewSeries(name, unit, series, series.LineStyle.Color))
+}
+
+func (w *Widget) SetStreamingWindow(window float64) *Widget {
+	w.plotMu.Lock()
+	w.streamWindow = window
+	w.following = true
+	w.plotMu.Unlock()
+	return w
+}
+
+func (w *Widget) SetMaxRefreshRate(fps float64) *Widget {
+	w.refreshInterval = time.Duration(float64(time.Second) / fps)
+	return w
+}
+
+func (w *Widget) Follow() {
+	w.plotMu.Lock()
+	w.following = w.streamWindow > 0
+	w.plotMu.Unlock()
+	w.Refresh()
+}
+
+func (w *Widget) requestRefresh() {
+	w.refreshMu.Lock()
+	defer w.refreshMu.Unlock()
+	if w.refreshPending {
+		return
+	}
+	wait := w.refreshInterval - time.Since(w.lastRefresh)
+	if wait <= 0 {
+		w.lastRefresh = time.Now()
+		go w.Refresh()
+		return
+	}
+	w.refreshPending = true
+	time.AfterFunc(wait, func() {
+		w.refreshMu.Lock()
+		w.refreshPending = false
+		w.lastRefresh = time.Now()
+		w.refreshMu.Unlock()
+		w.Refresh()
+	})
+}
+
+func (w *Widget) followStreams() {
+	if !w.following || len(w.streams) == 0 {
+		return
+	}
+	latest := math.Inf(-1)
+	for _, series := range w.streams {
+		if x, _, ok := series.Last(); ok {
+			latest = math.Max(latest, x)
+		}
+	}
+	if math.IsInf(latest, -1) {
+		return
+	}
+	w.Plot.X.Min = latest - w.streamWindow
+	w.Plot.X.Max = latest
+
+	if yMin, yMax, ok := w.streamRange(AxisLeft); ok {
+		w.Plot.Y.Min = yMin
+		w.Plot.Y.Max = yMax
+	}
+	if w.rightAxis == nil {
+		return
+	}
+	if yMin, yMax, ok := w.streamRange(AxisRight); ok {
+		w.rightAxis.fit(w.Plot.Y.Min, w.Plot.Y.Max, yMin, yMax)
+	}
+}
+
+func (w *Widget) streamRange(axis Axis) (float64, float64, bool) {
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, series := range w.series {
+		if _, ok := series.data.(*StreamingSeries); !ok || !series.Visible || series.Axis != axis {
+			continue
+		}
+		seriesYMin, seriesYMax := series.rangeIn(w.Plot.X.Min, w.Plot.X.Max)
+		yMin = math.Min(yMin, seriesYMin)
+		yMax = math.Max(yMax, seriesYMax)
+	}
+	if math.IsInf(yMin, 1) {
+		return 0, 0, false
+	}
+	padding := (yMax - yMin) * 0.05
+	if padding == 0 {
+		padding = 1
+	}
+	return yMin - padding, yMax + padding, true
+}
+
 func (w *Widget) SetMinWidgetSize(size fyne.Size) {
 	w.MinWidgetSize = size
 }
 
 func (w *Widget) render() image.Image {
+	w.followStreams()
 	img := image.NewRGBA(image.Rect(0, 0, int(w.Size().Width), int(w.Size().Height-w.buttonContainer.Size().Height)))
 	c := vgimg.NewWith(vgimg.UseImage(img))
-	dc := draw.New(c)
-	w.Plot.Draw(dc)
+	w.draw(draw.New(c))
 
 	for _, tool := range w.tools {
 		tool.onRender(c)
@@ -88,6 +242,28 @@ func (w *Widget) render() image.Image {
 	return c.Image()
 }
 
+func (w *Widget) draw(dc draw.Canvas) {
+	plotCanvas := dc
+	if w.rightAxis != nil {
+		dc.SetColor(w.Plot.BackgroundColor)
+		dc.Fill(dc.Rectangle.Path())
+		plotCanvas.Max.X -= w.rightAxisWidth()
+	}
+	w.Plot.Draw(plotCanvas)
+	dataCanvas := w.Plot.DataCanvas(plotCanvas)
+	w.dataArea = dataCanvas.Rectangle
+
+	for _, series := range w.series {
+		if series.Visible {
+			series.plot(dataCanvas, w.Plot, w.toPlotY(series))
+		}
+	}
+	if w.rightAxis != nil {
+		w.drawRightAxis(dc)
+	}
+	w.drawLegend(dc)
+}
+
 func (w *Widget) pixelToDotsX(x float64) float64 {
 	scale := float64(vg.Inch / vgimg.DefaultDPI)
 	return x * scale
@@ -97,30 +273,235 @@ func (w *Widget) pixelToDotsY(y float64) float64 {
 	return (float64(w.Size().Height) - y - float64(w.buttonContainer.Size().Height)) * scale
 }
 
+func (w *Widget) dotsToPixelX(x vg.Length) float32 {
+	scale := float64(vg.Inch / vgimg.DefaultDPI)
+	return float32(float64(x) / scale)
+}
+
+func (w *Widget) dotsToPixelY(y vg.Length) float32 {
+	scale := float64(vg.Inch / vgimg.DefaultDPI)
+	return w.Size().Height - w.buttonContainer.Size().Height - float32(float64(y)/scale)
+}
+
+func (w *Widget) pixelToData(position fyne.Position) (float64, float64) {
+	dotsX := w.pixelToDotsX(float64(position.X))
+	dotsY := w.pixelToDotsY(float64(position.Y))
+	area := w.dataArea
+	if area.Size().X <= 0 || area.Size().Y <= 0 {
+		return w.Plot.X.Min, w.Plot.Y.Min
+	}
+	x := w.Plot.X.Min + (dotsX-float64(area.Min.X))/float64(area.Size().X)*(w.Plot.X.Max-w.Plot.X.Min)
+	y := w.Plot.Y.Min + (dotsY-float64(area.Min.Y))/float64(area.Size().Y)*(w.Plot.Y.Max-w.Plot.Y.Min)
+	return x, y
+}
+
+func (w *Widget) dataToDots(x, y float64) vg.Point {
+	area := w.dataArea
+	return vg.Point{
+		X: area.Min.X + vg.Length(w.Plot.X.Norm(x))*area.Size().X,
+		Y: area.Min.Y + vg.Length(w.Plot.Y.Norm(y))*area.Size().Y,
+	}
+}
+
+func (w *Widget) dataToPixel(x, y float64) fyne.Position {
+	dots := w.dataToDots(x, y)
+	return fyne.NewPos(w.dotsToPixelX(dots.X), w.dotsToPixelY(dots.Y))
+}
+
+func (w *Widget) dataAreaPixelSize() (float64, float64) {
+	scale := float64(vg.Inch / vgimg.DefaultDPI)
+	return float64(w.dataArea.Size().X) / scale, float64(w.dataArea.Size().Y) / scale
+}
+
+func (w *Widget) currentAxisRange() axisRange {
+	return axisRange{xMin: w.Plot.X.Min, xMax: w.Plot.X.Max, yMin: w.Plot.Y.Min, yMax: w.Plot.Y.Max}
+}
+
+func (w *Widget) setAxisRange(r axisRange) {
+	w.Plot.X.Min = r.xMin
+	w.Plot.X.Max = r.xMax
+	w.Plot.Y.Min = r.yMin
+	w.Plot.Y.Max = r.yMax
+}
+
+func (w *Widget) recordHistory() {
+	w.history = append(w.history, w.currentAxisRange())
+	if len(w.history) > maxHistory {
+		w.history = w.history[1:]
+	}
+	w.future = nil
+}
+
+func (w *Widget) recordScrollHistory() {
+	if time.Since(w.lastScroll) > historyThrottleTimeout {
+		w.recordHistory()
+	}
+	w.lastScroll = time.Now()
+}
+
+func (w *Widget) historyBack() {
+	w.plotMu.Lock()
+	if len(w.history) == 0 {
+		w.plotMu.Unlock()
+		return
+	}
+	w.future = append(w.future, w.currentAxisRange())
+	w.setAxisRange(w.history[len(w.history)-1])
+	w.history = w.history[:len(w.history)-1]
+	w.following = false
+	w.plotMu.Unlock()
+	w.Refresh()
+	w.axisChanged()
+}
+
+func (w *Widget) historyForward() {
+	w.plotMu.Lock()
+	if len(w.future) == 0 {
+		w.plotMu.Unlock()
+		return
+	}
+	w.history = append(w.history, w.currentAxisRange())
+	w.setAxisRange(w.future[len(w.future)-1])
+	w.future = w.future[:len(w.future)-1]
+	w.following = false
+	w.plotMu.Unlock()
+	w.Refresh()
+	w.axisChanged()
+}
+
 func (w *Widget) resetAxis() {
+	w.plotMu.Lock()
+	if w.dataArea.Size().X > 0 {
+		w.recordHistory()
+	}
 	w.Plot.X.Min = w.PlotXMin
 	w.Plot.X.Max = w.PlotXMax
 	w.Plot.Y.Min = w.PlotYMin
 	w.Plot.Y.Max = w.PlotYMax
+	if w.rightAxis != nil {
+		w.rightAxis.fit(w.PlotYMin, w.PlotYMax, w.rightAxis.min, w.rightAxis.max)
+	}
+	w.following = w.streamWindow > 0
+	w.plotMu.Unlock()
 	w.Refresh()
+	w.axisChanged()
+}
+
+func (w *Widget) axisChanged() {
+	if w.linkGroup != nil {
+		w.linkGroup.axisChanged(w)
+	}
+}
+
+func (w *Widget) window() fyne.Window {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return nil
+	}
+	canvas := app.Driver().CanvasForObject(w)
+	for _, window := range app.Driver().AllWindows() {
+		if window.Canvas() == canvas {
+			return window
+		}
+	}
+	return nil
+}
+
+func (w *Widget) cursorTool() *CursorTool {
+	for _, tool := range w.tools {
+		if cursor, ok := tool.(*CursorTool); ok {
+			return cursor
+		}
+	}
+	return nil
 }
 
 func (w *Widget) Dragged(ev *fyne.DragEvent) {
+	w.plotMu.Lock()
+	w.following = false
 	for _, tool := range w.tools {
 		tool.onDrag(ev)
 	}
+	w.plotMu.Unlock()
 	w.Refresh()
+	w.axisChanged()
 }
 
 func (w *Widget) DragEnd() {
+	w.plotMu.Lock()
 	for _, tool := range w.tools {
 		tool.onDragEnd()
 	}
+	w.plotMu.Unlock()
+	w.Refresh()
+	w.axisChanged()
+}
+
+func (w *Widget) Scrolled(ev *fyne.ScrollEvent) {
+	scrolled := false
+	w.plotMu.Lock()
+	for _, tool := range w.tools {
+		if tool.hasIntent("scroll") {
+			tool.onScroll(ev)
+			scrolled = true
+		}
+	}
+	w.plotMu.Unlock()
+	if scrolled {
+		w.Refresh()
+		w.axisChanged()
+	}
+}
+
+func (w *Widget) MouseIn(ev *desktop.MouseEvent) {
+	w.MouseMoved(ev)
+}
+
+func (w *Widget) MouseMoved(ev *desktop.MouseEvent) {
+	hovered := false
+	w.plotMu.Lock()
+	for _, tool := range w.tools {
+		if tool.hasIntent("hover") && tool.IsEnabled() {
+			tool.onHover(ev.Position)
+			hovered = true
+		}
+	}
+	w.plotMu.Unlock()
+	if hovered {
+		w.requestRefresh()
+	}
+}
+
+func (w *Widget) MouseOut() {
+	for _, tool := range w.tools {
+		if tool.hasIntent("hover") {
+			tool.onHoverEnd()
+		}
+	}
+	w.requestRefresh()
+}
+
+func (w *Widget) Tapped(ev *fyne.PointEvent) {
+	w.plotMu.Lock()
+	if series := w.legendEntryAt(ev.Position); series != nil {
+		series.Visible = !series.Visible
+		w.plotMu.Unlock()
+		w.Refresh()
+		return
+	}
+	for _, tool := range w.tools {
+		tool.onTap(ev.Position)
+	}
+	w.plotMu.Unlock()
 	w.Refresh()
 }
 
+// Refresh renders the graph. Renders are serialized, so streaming appends, user input and linked widgets can refresh
+// from any goroutine
 func (w *Widget) Refresh() {
+	w.plotMu.Lock()
 	w.image.Image = w.render()
+	w.plotMu.Unlock()
 	w.image.Refresh()
 }
 
@@ -0,0 +1,25 @@
+package Graph
+
+import "fyne.io/fyne/v2/widget"
+
+type HistoryTool struct {
+	ToolBase
+}
+
+func NewHistoryTool() *HistoryTool {
+	t := &HistoryTool{}
+	t.intents.axis = true
+	t.intents.button = true
+	return t
+}
+
+func (t *HistoryTool) registerButtons() {
+	if t.widget == nil {
+		return
+	}
+
+	t.widget.buttons = append(t.widget.buttons,
+		widget.NewButton("Back", t.widget.historyBack),
+		widget.NewButton("Forward", t.widget.historyForward),
+	)
+}
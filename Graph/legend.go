@@ -0,0 +1,86 @@
+package Graph
+
+import (
+	"fyne.io/fyne/v2"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"image/color"
+	"math"
+)
+
+type legendEntry struct {
+	series *Series
+	box    vg.Rectangle
+}
+
+var (
+	legendBackgroundColor = color.NRGBA{R: 40, G: 40, B: 40, A: 200}
+	legendHiddenColor     = color.NRGBA{R: 128, G: 128, B: 128, A: 255}
+)
+
+func (w *Widget) SetLegendVisible(visible bool) *Widget {
+	w.showLegend = visible
+	return w
+}
+
+func (w *Widget) drawLegend(dc draw.Canvas) {
+	w.legend = nil
+	if !w.showLegend || len(w.series) == 0 {
+		return
+	}
+	style := w.Plot.Y.Tick.Label
+	style.XAlign = draw.XLeft
+	style.YAlign = draw.YCenter
+	padding := vg.Points(4)
+	swatchWidth := vg.Points(14)
+
+	textWidth, rowHeight := vg.Length(0), swatchWidth
+	for _, series := range w.series {
+		textWidth = vg.Length(math.Max(float64(textWidth), float64(style.Width(series.Label()))))
+		rowHeight = vg.Length(math.Max(float64(rowHeight), float64(style.Height(series.Label()))))
+	}
+	rowHeight += padding
+	boxWidth := swatchWidth + textWidth + 3*padding
+
+	area := w.dataArea
+	top := area.Max.Y - padding
+	left := area.Max.X - padding - boxWidth
+	background := vg.Rectangle{
+		Min: vg.Point{X: left, Y: top - rowHeight*vg.Length(len(w.series)) - padding},
+		Max: vg.Point{X: left + boxWidth, Y: top},
+	}
+	dc.SetColor(legendBackgroundColor)
+	dc.Fill(background.Path())
+
+	for i, series := range w.series {
+		center := top - padding/2 - rowHeight*(vg.Length(i)+0.5)
+		seriesColor, textStyle := series.Color, style
+		if !series.Visible {
+			seriesColor = legendHiddenColor
+			textStyle.Color = legendHiddenColor
+		}
+		swatchStart := left + padding
+		if series.Style != StyleScatter {
+			dc.StrokeLine2(draw.LineStyle{Color: seriesColor, Width: vg.Points(2)}, swatchStart, center, swatchStart+swatchWidth, center)
+		}
+		if series.Style != StyleLine {
+			dc.DrawGlyph(draw.GlyphStyle{Color: seriesColor, Radius: vg.Points(3), Shape: draw.RingGlyph{}}, vg.Point{X: swatchStart + swatchWidth/2, Y: center})
+		}
+		dc.FillText(textStyle, vg.Point{X: swatchStart + swatchWidth + padding, Y: center}, series.Label())
+
+		w.legend = append(w.legend, legendEntry{series: series, box: vg.Rectangle{
+			Min: vg.Point{X: left, Y: center - rowHeight/2},
+			Max: vg.Point{X: left + boxWidth, Y: center + rowHeight/2},
+		}})
+	}
+}
+
+func (w *Widget) legendEntryAt(position fyne.Position) *Series {
+	point := vg.Point{X: vg.Length(w.pixelToDotsX(float64(position.X))), Y: vg.Length(w.pixelToDotsY(float64(position.Y)))}
+	for _, entry := range w.legend {
+		if rectangleContains(entry.box, point) {
+			return entry.series
+		}
+	}
+	return nil
+}
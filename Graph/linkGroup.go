@@ -0,0 +1,88 @@
+package Graph
+
+import "sync"
+
+type LinkGroup struct {
+	widgets []*Widget
+	linkY   bool
+	mu      sync.Mutex
+}
+
+func NewLinkGroup(linkY bool) *LinkGroup {
+	return &LinkGroup{linkY: linkY}
+}
+
+func (g *LinkGroup) Add(w *Widget) *LinkGroup {
+	if w.linkGroup == g {
+		return g
+	}
+	// The old group is left before this group is locked, so no two group locks are ever held at once
+	if w.linkGroup != nil {
+		w.linkGroup.Remove(w)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	w.linkGroup = g
+	g.widgets = append(g.widgets, w)
+	return g
+}
+
+func (g *LinkGroup) Remove(w *Widget) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, other := range g.widgets {
+		if other == w {
+			g.widgets = append(g.widgets[:i:i], g.widgets[i+1:]...)
+			w.linkGroup = nil
+			return
+		}
+	}
+}
+
+func (g *LinkGroup) others(source *Widget) []*Widget {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var others []*Widget
+	for _, w := range g.widgets {
+		if w != source {
+			others = append(others, w)
+		}
+	}
+	return others
+}
+
+func (g *LinkGroup) axisChanged(source *Widget) {
+	source.plotMu.Lock()
+	following := source.following
+	r := source.currentAxisRange()
+	source.plotMu.Unlock()
+
+	for _, w := range g.others(source) {
+		w.plotMu.Lock()
+		w.following = following
+		if !g.linkY {
+			r.yMin, r.yMax = w.Plot.Y.Min, w.Plot.Y.Max
+		}
+		w.setAxisRange(r)
+		w.plotMu.Unlock()
+		w.Refresh()
+	}
+}
+
+func (g *LinkGroup) cursorMoved(source *Widget, x float64, hovering bool) {
+	for _, w := range g.others(source) {
+		if cursor := w.cursorTool(); cursor != nil {
+			cursor.mirror(x, hovering)
+			w.requestRefresh()
+		}
+	}
+}
+
+func (g *LinkGroup) cursorsPinned(source *Widget, pinned []float64) {
+	for _, w := range g.others(source) {
+		if cursor := w.cursorTool(); cursor != nil {
+			cursor.pinned = append([]float64(nil), pinned...)
+			w.requestRefresh()
+		}
+	}
+}
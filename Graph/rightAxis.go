@@ -0,0 +1,102 @@
+package Graph
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"math"
+)
+
+type rightAxis struct {
+	label  string
+	min    float64
+	max    float64
+	offset float64
+	factor float64
+}
+
+func (a *rightAxis) fit(leftMin, leftMax, min, max float64) {
+	if leftMax == leftMin || max == min {
+		a.factor = 1
+		a.offset = min - leftMin
+		return
+	}
+	a.factor = (max - min) / (leftMax - leftMin)
+	a.offset = min - a.factor*leftMin
+}
+
+func (a *rightAxis) toLeft(y float64) float64 {
+	return (y - a.offset) / a.factor
+}
+
+func (a *rightAxis) fromLeft(y float64) float64 {
+	return a.offset + a.factor*y
+}
+
+func (w *Widget) SetRightAxis(label string, min, max float64) *Widget {
+	w.rightAxis = &rightAxis{label: label, min: min, max: max}
+	w.rightAxis.fit(w.Plot.Y.Min, w.Plot.Y.Max, min, max)
+	return w
+}
+
+func (w *Widget) toPlotY(series *Series) func(float64) float64 {
+	if series.Axis == AxisRight && w.rightAxis != nil {
+		return w.rightAxis.toLeft
+	}
+	return func(y float64) float64 { return y }
+}
+
+func (w *Widget) rightAxisTicks() []plot.Tick {
+	return w.Plot.Y.Tick.Marker.Ticks(w.rightAxis.fromLeft(w.Plot.Y.Min), w.rightAxis.fromLeft(w.Plot.Y.Max))
+}
+
+func (w *Widget) rightAxisWidth() vg.Length {
+	labelWidth := vg.Length(0)
+	for _, tick := range w.rightAxisTicks() {
+		if tick.Label != "" {
+			labelWidth = vg.Length(math.Max(float64(labelWidth), float64(w.Plot.Y.Tick.Label.Width(tick.Label))))
+		}
+	}
+	width := w.Plot.Y.Padding + w.Plot.Y.Tick.Length + labelWidth + 3*w.Plot.Y.Label.Padding
+	if w.rightAxis.label != "" {
+		width += w.Plot.Y.Label.TextStyle.Height(w.rightAxis.label)
+	}
+	return width
+}
+
+func (w *Widget) drawRightAxis(dc draw.Canvas) {
+	area := w.dataArea
+	x := area.Max.X + w.Plot.Y.Padding
+	dc.StrokeLine2(w.Plot.Y.LineStyle, x, area.Min.Y, x, area.Max.Y)
+
+	labelStyle := w.Plot.Y.Tick.Label
+	labelStyle.XAlign = draw.XLeft
+	labelStyle.YAlign = draw.YCenter
+	labelWidth := vg.Length(0)
+	for _, tick := range w.rightAxisTicks() {
+		leftY := w.rightAxis.toLeft(tick.Value)
+		if leftY < w.Plot.Y.Min || leftY > w.Plot.Y.Max {
+			continue
+		}
+		y := area.Min.Y + vg.Length(w.Plot.Y.Norm(leftY))*area.Size().Y
+		length := w.Plot.Y.Tick.Length
+		if tick.IsMinor() {
+			length /= 2
+		}
+		dc.StrokeLine2(w.Plot.Y.Tick.LineStyle, x, y, x+length, y)
+		if tick.Label != "" {
+			dc.FillText(labelStyle, vg.Point{X: x + w.Plot.Y.Tick.Length + w.Plot.Y.Label.Padding, Y: y}, tick.Label)
+			labelWidth = vg.Length(math.Max(float64(labelWidth), float64(labelStyle.Width(tick.Label))))
+		}
+	}
+
+	if w.rightAxis.label == "" {
+		return
+	}
+	titleStyle := w.Plot.Y.Label.TextStyle
+	titleStyle.Rotation = -math.Pi / 2
+	titleStyle.XAlign = draw.XCenter
+	titleStyle.YAlign = draw.YBottom
+	titleX := x + w.Plot.Y.Tick.Length + labelWidth + 2*w.Plot.Y.Label.Padding
+	dc.FillText(titleStyle, vg.Point{X: titleX, Y: area.Min.Y + area.Size().Y/2}, w.rightAxis.label)
+}
@@ -0,0 +1,113 @@
+package Graph
+
+import (
+	"fmt"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+	"image/color"
+	"math"
+)
+
+type SeriesStyle string
+
+const (
+	StyleLine        SeriesStyle = "Line"
+	StyleScatter     SeriesStyle = "Scatter"
+	StyleLineScatter SeriesStyle = "Line + scatter"
+)
+
+var SeriesStyles = []SeriesStyle{StyleLine, StyleScatter, StyleLineScatter}
+
+type Axis string
+
+const (
+	AxisLeft  Axis = "Left"
+	AxisRight Axis = "Right"
+)
+
+type Series struct {
+	Name    string
+	Unit    string
+	Color   color.Color
+	Style   SeriesStyle
+	Axis    Axis
+	Visible bool
+	data    plotter.XYer
+}
+
+func NewSeries(name, unit string, data plotter.XYer, seriesColor color.Color) *Series {
+	return &Series{
+		Name:    name,
+		Unit:    unit,
+		Color:   seriesColor,
+		Style:   StyleLine,
+		Axis:    AxisLeft,
+		Visible: true,
+		data:    data,
+	}
+}
+
+func (s *Series) SetStyle(style SeriesStyle) *Series {
+	s.Style = style
+	return s
+}
+
+func (s *Series) SetAxis(axis Axis) *Series {
+	s.Axis = axis
+	return s
+}
+
+func (s *Series) Label() string {
+	if s.Unit == "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (%s)", s.Name, s.Unit)
+}
+
+func (s *Series) format(value float64) string {
+	if s.Unit == "" {
+		return fmt.Sprintf("%.4g", value)
+	}
+	return fmt.Sprintf("%.4g %s", value, s.Unit)
+}
+
+func (s *Series) points() plotter.XYs {
+	if streaming, ok := s.data.(*StreamingSeries); ok {
+		return streaming.points()
+	}
+	points, err := plotter.CopyXYs(s.data)
+	if err != nil {
+		return nil
+	}
+	return points
+}
+
+func (s *Series) rangeIn(fromX, toX float64) (yMin, yMax float64) {
+	yMin, yMax = math.Inf(1), math.Inf(-1)
+	for _, point := range s.points() {
+		if point.X < fromX || point.X > toX {
+			continue
+		}
+		yMin = math.Min(yMin, point.Y)
+		yMax = math.Max(yMax, point.Y)
+	}
+	return yMin, yMax
+}
+
+func (s *Series) plot(c draw.Canvas, p *plot.Plot, toPlotY func(float64) float64) {
+	points := s.points()
+	for i := range points {
+		points[i].Y = toPlotY(points[i].Y)
+	}
+	if s.Style != StyleScatter && len(points) > 1 {
+		line := &plotter.Line{XYs: points, LineStyle: plotter.DefaultLineStyle}
+		line.LineStyle.Color = s.Color
+		line.Plot(c, p)
+	}
+	if s.Style != StyleLine {
+		scatter := &plotter.Scatter{XYs: points, GlyphStyle: plotter.DefaultGlyphStyle}
+		scatter.GlyphStyle.Color = s.Color
+		scatter.Plot(c, p)
+	}
+}
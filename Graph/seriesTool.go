@@ -0,0 +1,82 @@
+package Graph
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"image/color"
+)
+
+type SeriesTool struct {
+	ToolBase
+}
+
+func NewSeriesTool() *SeriesTool {
+	t := &SeriesTool{}
+	t.intents.button = true
+	return t
+}
+
+func (t *SeriesTool) registerButtons() {
+	if t.widget == nil {
+		return
+	}
+
+	t.widget.buttons = append(t.widget.buttons, widget.NewButton("Series", t.showPanel))
+}
+
+func (t *SeriesTool) showPanel() {
+	window := t.widget.window()
+	if window == nil {
+		return
+	}
+
+	var styles []string
+	for _, style := range SeriesStyles {
+		styles = append(styles, string(style))
+	}
+
+	rows := container.NewVBox()
+	for _, series := range t.widget.series {
+		series := series
+		visibleCheck := widget.NewCheck(series.Label(), func(visible bool) {
+			series.Visible = visible
+			t.widget.Refresh()
+		})
+		visibleCheck.SetChecked(series.Visible)
+
+		swatch := canvas.NewRectangle(series.Color)
+		swatch.SetMinSize(fyne.NewSize(20, 20))
+		colorButton := widget.NewButton("Color", func() {
+			picker := dialog.NewColorPicker("Series color", series.Label(), func(seriesColor color.Color) {
+				series.Color = seriesColor
+				swatch.FillColor = seriesColor
+				swatch.Refresh()
+				t.widget.Refresh()
+			}, window)
+			picker.Advanced = true
+			picker.Show()
+		})
+
+		styleSelect := widget.NewSelect(styles, func(style string) {
+			series.Style = SeriesStyle(style)
+			t.widget.Refresh()
+		})
+		styleSelect.SetSelected(string(series.Style))
+
+		axisSelect := widget.NewSelect([]string{string(AxisLeft), string(AxisRight)}, func(axis string) {
+			series.Axis = Axis(axis)
+			t.widget.Refresh()
+		})
+		axisSelect.SetSelected(string(series.Axis))
+		if t.widget.rightAxis == nil {
+			axisSelect.Disable()
+		}
+
+		rows.Add(container.NewHBox(visibleCheck, swatch, colorButton, styleSelect, axisSelect))
+	}
+
+	dialog.ShowCustom("Series", "Close", rows, window)
+}
@@ -0,0 +1,119 @@
+package Graph
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+	"image/color"
+	"math"
+	"sync"
+)
+
+type StreamingSeries struct {
+	LineStyle draw.LineStyle
+	xs        []float64
+	ys        []float64
+	start     int
+	count     int
+	onAppend  func()
+	mu        sync.RWMutex
+}
+
+// NewStreamingSeries creates a series that keeps the last capacity points. A capacity below 1 is raised to 1
+func NewStreamingSeries(capacity int, lineColor color.Color) *StreamingSeries {
+	capacity = max(capacity, 1)
+	s := &StreamingSeries{
+		LineStyle: plotter.DefaultLineStyle,
+		xs:        make([]float64, capacity),
+		ys:        make([]float64, capacity),
+	}
+	s.LineStyle.Color = lineColor
+	return s
+}
+
+func (s *StreamingSeries) Append(x, y float64) {
+	s.mu.Lock()
+	index := (s.start + s.count) % len(s.xs)
+	s.xs[index] = x
+	s.ys[index] = y
+	if s.count < len(s.xs) {
+		s.count++
+	} else {
+		s.start = (s.start + 1) % len(s.xs)
+	}
+	onAppend := s.onAppend
+	s.mu.Unlock()
+
+	if onAppend != nil {
+		onAppend()
+	}
+}
+
+func (s *StreamingSeries) Clear() {
+	s.mu.Lock()
+	s.start = 0
+	s.count = 0
+	s.mu.Unlock()
+}
+
+func (s *StreamingSeries) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+func (s *StreamingSeries) XY(i int) (float64, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index := (s.start + i) % len(s.xs)
+	return s.xs[index], s.ys[index]
+}
+
+func (s *StreamingSeries) Last() (float64, float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count == 0 {
+		return 0, 0, false
+	}
+	index := (s.start + s.count - 1) % len(s.xs)
+	return s.xs[index], s.ys[index], true
+}
+
+func (s *StreamingSeries) points() plotter.XYs {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	points := make(plotter.XYs, s.count)
+	for i := range points {
+		index := (s.start + i) % len(s.xs)
+		points[i].X = s.xs[index]
+		points[i].Y = s.ys[index]
+	}
+	return points
+}
+
+func (s *StreamingSeries) Plot(c draw.Canvas, p *plot.Plot) {
+	points := s.points()
+	if len(points) < 2 {
+		return
+	}
+	line := &plotter.Line{XYs: points, LineStyle: s.LineStyle}
+	line.Plot(c, p)
+}
+
+func (s *StreamingSeries) DataRange() (xMin, xMax, yMin, yMax float64) {
+	return s.rangeIn(math.Inf(-1), math.Inf(1))
+}
+
+func (s *StreamingSeries) rangeIn(fromX, toX float64) (xMin, xMax, yMin, yMax float64) {
+	xMin, xMax, yMin, yMax = math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, point := range s.points() {
+		if point.X < fromX || point.X > toX {
+			continue
+		}
+		xMin = math.Min(xMin, point.X)
+		xMax = math.Max(xMax, point.X)
+		yMin = math.Min(yMin, point.Y)
+		yMax = math.Max(yMax, point.Y)
+	}
+	return xMin, xMax, yMin, yMax
+}
@@ -9,13 +9,20 @@ type intents struct {
 	drag,
 	render,
 	axis,
-	button bool
+	button,
+	hover,
+	tap,
+	scroll bool
 }
 
 type tool interface {
 	onDrag(ev *fyne.DragEvent)
 	onDragEnd()
 	onRender(c *vgimg.Canvas)
+	onHover(position fyne.Position)
+	onHoverEnd()
+	onTap(position fyne.Position)
+	onScroll(ev *fyne.ScrollEvent)
 	setWidget(w *Widget)
 	getIntents() intents
 	hasIntent(intent string) bool
@@ -47,6 +54,12 @@ func (t *ToolBase) hasIntent(intent string) bool {
 		return t.intents.axis
 	case "button":
 		return t.intents.button
+	case "hover":
+		return t.intents.hover
+	case "tap":
+		return t.intents.tap
+	case "scroll":
+		return t.intents.scroll
 	}
 	return false
 }
@@ -73,4 +86,12 @@ func (t *ToolBase) onDragEnd() {}
 
 func (t *ToolBase) onRender(c *vgimg.Canvas) {}
 
+func (t *ToolBase) onHover(position fyne.Position) {}
+
+func (t *ToolBase) onHoverEnd() {}
+
+func (t *ToolBase) onTap(position fyne.Position) {}
+
+func (t *ToolBase) onScroll(ev *fyne.ScrollEvent) {}
+
 func (t *ToolBase) registerButtons() {}
@@ -6,10 +6,26 @@ import (
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/vgimg"
 	"image/color"
+	"math"
+)
+
+type ZoomAxes int
+
+const (
+	ZoomBoth ZoomAxes = iota
+	ZoomX
+	ZoomY
+)
+
+const (
+	minZoomSelection = 5
+	scrollZoomBase   = 0.98
 )
 
 type ZoomTool struct {
 	ToolBase
+	axes             ZoomAxes
+	selecting        bool
 	currentSelection [4]float64
 }
 
@@ -19,6 +35,7 @@ func NewZoomTool() *ZoomTool {
 	z.intents.render = true
 	z.intents.axis = true
 	z.intents.button = true
+	z.intents.scroll = true
 	return z
 }
 
@@ -27,7 +44,16 @@ func (z *ZoomTool) registerButtons() {
 		return
 	}
 
-	z.widget.buttons = append(z.widget.buttons, widget.NewButton("Zoom", z.Enable))
+	z.widget.buttons = append(z.widget.buttons,
+		widget.NewButton("Zoom", func() { z.enableAxes(ZoomBoth) }),
+		widget.NewButton("Zoom X", func() { z.enableAxes(ZoomX) }),
+		widget.NewButton("Zoom Y", func() { z.enableAxes(ZoomY) }),
+	)
+}
+
+func (z *ZoomTool) enableAxes(axes ZoomAxes) {
+	z.axes = axes
+	z.Enable()
 }
 
 func (z *ZoomTool) Enable() {
@@ -43,47 +69,74 @@ func (z *ZoomTool) onDrag(ev *fyne.DragEvent) {
 	if !z.active {
 		return
 	}
-	if z.currentSelection[0] == 0 && z.currentSelection[1] == 0 {
-		z.currentSelection[0] = float64(ev.Position.X)
-		z.currentSelection[1] = float64(ev.Position.Y)
+	if !z.selecting {
+		z.selecting = true
+		z.currentSelection[0] = float64(ev.Position.X - ev.Dragged.DX)
+		z.currentSelection[1] = float64(ev.Position.Y - ev.Dragged.DY)
 	}
 	z.currentSelection[2] = float64(ev.Position.X)
 	z.currentSelection[3] = float64(ev.Position.Y)
 }
 
 func (z *ZoomTool) onDragEnd() {
-	if !z.active {
+	if !z.active || !z.selecting {
+		return
+	}
+	z.selecting = false
+	if math.Abs(z.currentSelection[2]-z.currentSelection[0]) < minZoomSelection && z.axes != ZoomY ||
+		math.Abs(z.currentSelection[3]-z.currentSelection[1]) < minZoomSelection && z.axes != ZoomX {
+		return
+	}
+
+	x1, y1 := z.widget.pixelToData(fyne.NewPos(float32(z.currentSelection[0]), float32(z.currentSelection[1])))
+	x2, y2 := z.widget.pixelToData(fyne.NewPos(float32(z.currentSelection[2]), float32(z.currentSelection[3])))
+	z.widget.recordHistory()
+	z.widget.following = false
+	if z.axes != ZoomY {
+		z.widget.Plot.X.Min = math.Min(x1, x2)
+		z.widget.Plot.X.Max = math.Max(x1, x2)
+	}
+	if z.axes != ZoomX {
+		z.widget.Plot.Y.Min = math.Min(y1, y2)
+		z.widget.Plot.Y.Max = math.Max(y1, y2)
+	}
+}
+
+func (z *ZoomTool) onScroll(ev *fyne.ScrollEvent) {
+	factor := math.Pow(scrollZoomBase, float64(ev.Scrolled.DY))
+	if factor == 1 {
 		return
 	}
-	widthPlot := z.widget.Plot.X.Max - z.widget.Plot.X.Min
-	xScale := widthPlot / float64(z.widget.Size().Width)
-	newXMin := z.currentSelection[0] * xScale
-	newXMax := z.currentSelection[2] * xScale
-	heightPlot := z.widget.Plot.Y.Max - z.widget.Plot.Y.Min
-	yScale := heightPlot / float64(z.widget.Size().Height-z.widget.buttonContainer.Size().Height)
-	newYMin := heightPlot - z.currentSelection[1]*yScale
-	newYMax := heightPlot - z.currentSelection[3]*yScale
-	z.widget.Plot.X.Min = newXMin
-	z.widget.Plot.X.Max = newXMax
-	z.widget.Plot.Y.Min = newYMin
-	z.widget.Plot.Y.Max = newYMax
-	z.currentSelection[0] = 0
-	z.currentSelection[1] = 0
-	z.currentSelection[2] = 0
-	z.currentSelection[3] = 0
+	z.widget.recordScrollHistory()
+	z.widget.following = false
+	x, y := z.widget.pixelToData(ev.Position)
+	if z.axes != ZoomY {
+		z.widget.Plot.X.Min = x - (x-z.widget.Plot.X.Min)*factor
+		z.widget.Plot.X.Max = x + (z.widget.Plot.X.Max-x)*factor
+	}
+	if z.axes != ZoomX {
+		z.widget.Plot.Y.Min = y - (y-z.widget.Plot.Y.Min)*factor
+		z.widget.Plot.Y.Max = y + (z.widget.Plot.Y.Max-y)*factor
+	}
 }
 
 func (z *ZoomTool) onRender(c *vgimg.Canvas) {
-	if !z.active {
+	if !z.active || !z.selecting {
 		return
 	}
-	if z.currentSelection[0] != 0 || z.currentSelection[1] != 0 || z.currentSelection[2] != 0 || z.currentSelection[3] != 0 {
-		rect := vg.Rectangle{
-			Min: vg.Point{X: vg.Length(z.widget.pixelToDotsX(z.currentSelection[0])), Y: vg.Length(z.widget.pixelToDotsY(z.currentSelection[1]))},
-			Max: vg.Point{X: vg.Length(z.widget.pixelToDotsX(z.currentSelection[2])), Y: vg.Length(z.widget.pixelToDotsY(z.currentSelection[3]))},
-		}
-
-		c.SetColor(color.NRGBA{R: 173, G: 216, B: 230, A: 150})
-		c.Fill(rect.Path())
+	rect := vg.Rectangle{
+		Min: vg.Point{X: vg.Length(z.widget.pixelToDotsX(z.currentSelection[0])), Y: vg.Length(z.widget.pixelToDotsY(z.currentSelection[1]))},
+		Max: vg.Point{X: vg.Length(z.widget.pixelToDotsX(z.currentSelection[2])), Y: vg.Length(z.widget.pixelToDotsY(z.currentSelection[3]))},
+	}
+	switch z.axes {
+	case ZoomX:
+		rect.Min.Y = z.widget.dataArea.Min.Y
+		rect.Max.Y = z.widget.dataArea.Max.Y
+	case ZoomY:
+		rect.Min.X = z.widget.dataArea.Min.X
+		rect.Max.X = z.widget.dataArea.Max.X
 	}
+
+	c.SetColor(color.NRGBA{R: 173, G: 216, B: 230, A: 150})
+	c.Fill(rect.Path())
 }
@@ -17,24 +17,45 @@ import (
 	"time"
 )
 
-var (
-	Width            = Pixel(800)
-	Height           = Pixel(600)
-	resolutionFactor = 1.0
+const (
+	faceChunkSize = 256   // Number of faces that are transformed and projected together by one worker
+	defaultWidth  = 800.0 // Size the widget renders at before it is laid out
+	defaultHeight = 600.0
 )
 
+// faceBatch is a list of faces in local space together with the Transform to world space
+type faceBatch struct {
+	faces     []FaceData
+	transform Transform
+}
+
 // ThreeDWidget is a widget that displays 3D objects
 type ThreeDWidget struct {
 	widget.BaseWidget
-	image              *canvas.Image // The image that is rendered on
-	camera             *Camera       // The camera of the 3D widget
-	objects            []*Object     // The objects in the 3D widget
-	tickMethods        []func()      // The methods that are called every frame
-	bgColor            color.Color   // The background color of the 3D widget
-	renderFaceOutlines bool          // Whether the faces should be rendered with outlines
-	renderFaceColors   bool          // Whether the faces should be rendered with colors
-	fpsCap             float64       // The maximum frames per second the widget should render at
-	tpsCap             float64       // The maximum ticks per second the widget should tick at
+	image              *canvas.Image                  // The image that is rendered on
+	camera             *Camera                        // The camera of the 3D widget
+	objects            []*Object                      // The objects in the 3D widget
+	tickMethods        []func()                       // The methods that are called every frame
+	bgColor            color.Color                    // The background color of the 3D widget
+	skyHorizon         color.Color                    // The color of the sky at the horizon. nil if the background color is used
+	skyZenith          color.Color                    // The color of the sky straight up
+	renderFaceOutlines bool                           // Whether the faces should be rendered with outlines
+	renderFaceColors   bool                           // Whether the faces should be rendered with colors
+	fpsCap             float64                        // The maximum frames per second the widget should render at
+	tpsCap             float64                        // The maximum ticks per second the widget should tick at
+	labels             []*Label                       // The labels drawn on top of the scene
+	billboards         []*Billboard                   // The billboards drawn on top of the scene
+	hudElements        []hudElement                   // The text elements of the HUD
+	showFPS            bool                           // Whether the measured FPS are shown in the HUD
+	fps                float64                        // The measured frames per second
+	lastFrameTime      time.Time                      // The time the last frame was started at
+	onTapped           func(fyne.Position, Hit, bool) // Called with the picked Object when the scene is tapped
+	onHovered          func(fyne.Position, Hit, bool) // Called with the picked Object when the mouse moves over the scene
+	width              Pixel                          // The fixed render width of an offscreen widget. 0 if the size follows the layout
+	height             Pixel                          // The fixed render height of an offscreen widget. 0 if the size follows the layout
+	size               fyne.Size                      // The size the widget was laid out at
+	resolutionFactor   float64                        // Factor between the layout size and the render resolution
+	mu                 sync.Mutex                     // Guards the size, the resolution factor and the overlay slices
 }
 
 // NewThreeDWidget creates a new 3D widget
@@ -47,6 +68,8 @@ func NewThreeDWidget() *ThreeDWidget {
 	standardCamera := NewCamera(Point3D{}, Rotation3D{})
 	w.camera = &standardCamera
 	w.objects = []*Object{}
+	w.size = fyne.NewSize(defaultWidth, defaultHeight)
+	w.resolutionFactor = 1
 	w.image = canvas.NewImageFromImage(w.render())
 	w.fpsCap = math.Inf(1)
 	w.tpsCap = math.Inf(1)
@@ -55,6 +78,33 @@ func NewThreeDWidget() *ThreeDWidget {
 	return w
 }
 
+// NewOffscreenThreeDWidget creates a 3D widget that renders at a fixed size and is not shown on screen.
+// It has no render or tick loop, call Tick and RenderFrame to step through the scene
+func NewOffscreenThreeDWidget(width, height Pixel) *ThreeDWidget {
+	w := &ThreeDWidget{}
+	w.bgColor = color.Transparent
+	w.renderFaceColors = true
+	standardCamera := NewCamera(Point3D{}, Rotation3D{})
+	w.camera = &standardCamera
+	w.objects = []*Object{}
+	w.width = width
+	w.height = height
+	w.resolutionFactor = 1
+	return w
+}
+
+// Tick calls all registered tick methods once
+func (w *ThreeDWidget) Tick() {
+	for _, tickMethod := range w.tickMethods {
+		tickMethod()
+	}
+}
+
+// RenderFrame renders the scene as it is now and returns the image
+func (w *ThreeDWidget) RenderFrame() image.Image {
+	return w.render()
+}
+
 func (w *ThreeDWidget) tickLoop() {
 	for {
 		if w.tpsCap == 0 || !w.Visible() {
@@ -63,9 +113,7 @@ func (w *ThreeDWidget) tickLoop() {
 		startTime := time.Now()
 		tickDuration := time.Second / time.Duration(w.tpsCap)
 
-		for _, tickMethod := range w.tickMethods {
-			tickMethod()
-		}
+		w.Tick()
 
 		elapsedTime := time.Since(startTime)
 		if elapsedTime < tickDuration {
@@ -84,6 +132,10 @@ func (w *ThreeDWidget) renderLoop() {
 		}
 		frameStartTime := time.Now()
 		frameDuration := time.Second / time.Duration(w.fpsCap)
+		if !w.lastFrameTime.IsZero() {
+			w.fps = w.fps*0.9 + 0.1/frameStartTime.Sub(w.lastFrameTime).Seconds()
+		}
+		w.lastFrameTime = frameStartTime
 
 		w.image.Image = w.render()
 		go canvas.Refresh(w.image)
@@ -108,16 +160,45 @@ func (w *ThreeDWidget) AddObject(object *Object) {
 	w.objects = append(w.objects, object)
 }
 
+// RemoveObject removes an Object from the widget. Its children have to be removed separately
+func (w *ThreeDWidget) RemoveObject(object *Object) {
+	for i, o := range w.objects {
+		if o == object {
+			w.objects = append(w.objects[:i:i], w.objects[i+1:]...)
+			return
+		}
+	}
+}
+
 func (w *ThreeDWidget) GetCamera() *Camera {
 	return w.camera
 }
 
+// GetWidth returns the width of the rendered image in pixels
 func (w *ThreeDWidget) GetWidth() Pixel {
-	return Width
+	if w.width != 0 {
+		return w.width
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return Pixel(float64(w.size.Width) * w.resolutionFactor)
 }
 
+// GetHeight returns the height of the rendered image in pixels
 func (w *ThreeDWidget) GetHeight() Pixel {
-	return Height
+	if w.height != 0 {
+		return w.height
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return Pixel(float64(w.size.Height) * w.resolutionFactor)
+}
+
+// getResolutionFactor returns the factor between positions on the widget and pixels of the rendered image
+func (w *ThreeDWidget) getResolutionFactor() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.resolutionFactor
 }
 
 // SetCamera sets the camera of the 3D widget
@@ -128,6 +209,13 @@ func (w *ThreeDWidget) SetCamera(camera *Camera) {
 // SetBackgroundColor sets the background color of the 3D widget
 func (w *ThreeDWidget) SetBackgroundColor(color color.Color) {
 	w.bgColor = color
+	w.skyHorizon, w.skyZenith = nil, nil
+}
+
+// SetSkyGradient replaces the background color with a sky that fades from the horizon color to the zenith color
+// depending on where the camera looks
+func (w *ThreeDWidget) SetSkyGradient(horizon, zenith color.Color) {
+	w.skyHorizon, w.skyZenith = horizon, zenith
 }
 
 // SetFPSCap sets the maximum frames per second the widget should render at
@@ -142,7 +230,9 @@ func (w *ThreeDWidget) SetTPSCap(tps float64) {
 
 // SetResolutionFactor sets the resolution factor of the 3D widget. This is a factor that is multiplied with the size of the widget to determine the resolution of the 3D rendering
 func (w *ThreeDWidget) SetResolutionFactor(factor float64) {
-	resolutionFactor = factor
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.resolutionFactor = factor
 }
 
 // SetRenderFaceOutlines sets whether the faces should be rendered with outlines.
@@ -161,53 +251,58 @@ func (w *ThreeDWidget) SetRenderFaceColors(newVal bool) {
 }
 
 func (w *ThreeDWidget) CreateRenderer() fyne.WidgetRenderer {
-	return &threeDRenderer{image: w.image}
+	return &threeDRenderer{widget: w, image: w.image}
 }
 
 func (w *ThreeDWidget) render() image.Image {
-	img := image.NewRGBA(image.Rect(0, 0, int(Width), int(Height)))
-	draw.Draw(img, img.Bounds(), &image.Uniform{C: w.bgColor}, image.Point{}, draw.Src)
-
-	var faces []FaceData
-	var wg3d sync.WaitGroup
-	var mu3d sync.Mutex
-	wg3d.Add(len(w.objects))
-	for _, object := range w.objects {
-		go func(object *Object) {
-			defer wg3d.Done()
-			objectFaces := object.GetFaces()
-			mu3d.Lock()
-			for _, face := range objectFaces {
-				if w.camera.IsInFrustum(face.Face[0]) || w.camera.IsInFrustum(face.Face[1]) || w.camera.IsInFrustum(face.Face[2]) {
-					faces = append(faces, face)
-				}
-			}
-			mu3d.Unlock()
-		}(object)
+	width, height := w.GetWidth(), w.GetHeight()
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	w.drawBackground(img, width, height)
+
+	// Select the visible objects and their level of detail
+	batches := make([]faceBatch, len(w.objects))
+	getRenderWorkers().run(len(w.objects), func(i int) {
+		object := w.objects[i]
+		center, radius := object.GetBoundingSphere()
+		if radius == 0 || !w.camera.IsSphereInFrustum(center, radius) {
+			return
+		}
+		screenSize := w.camera.ProjectedSize(center, radius, width)
+		batches[i] = faceBatch{faces: object.GetLODFaces(screenSize), transform: object.GetWorldTransform()}
+	})
+
+	// Transform and project the faces in chunks so large objects are spread over all workers
+	var chunks []faceBatch
+	for _, batch := range batches {
+		for start := 0; start < len(batch.faces); start += faceChunkSize {
+			end := min(start+faceChunkSize, len(batch.faces))
+			chunks = append(chunks, faceBatch{faces: batch.faces[start:end], transform: batch.transform})
+		}
 	}
-	wg3d.Wait()
+	projectedChunks := make([][]ProjectedFaceData, len(chunks))
+	getRenderWorkers().run(len(chunks), func(i int) {
+		faces := TransformFaces(chunks[i].faces, chunks[i].transform, w.camera.Position)
+		projected := make([]ProjectedFaceData, 0, len(faces))
+		for _, face := range faces {
+			if !w.camera.IsInFrustum(face.Face[0]) && !w.camera.IsInFrustum(face.Face[1]) && !w.camera.IsInFrustum(face.Face[2]) {
+				continue
+			}
+			p1 := w.camera.Project(face.Face[0], width, height)
+			p2 := w.camera.Project(face.Face[1], width, height)
+			p3 := w.camera.Project(face.Face[2], width, height)
 
-	var projectedFaces []ProjectedFaceData
-	var wg2d sync.WaitGroup
-	wg2d.Add(len(faces))
-	var mu sync.Mutex
-	for _, face := range faces {
-		go func(face FaceData) {
-			defer wg2d.Done()
-			p1 := w.camera.Project(face.Face[0], Width, Height)
-			p2 := w.camera.Project(face.Face[1], Width, Height)
-			p3 := w.camera.Project(face.Face[2], Width, Height)
-
-			if !p1.InBounds(0, 0, Width, Height) && !p2.InBounds(0, 0, Width, Height) && !p3.InBounds(0, 0, Width, Height) {
-				return
+			if !p1.InBounds(0, 0, width, height) && !p2.InBounds(0, 0, width, height) && !p3.InBounds(0, 0, width, height) {
+				continue
 			}
+			projected = append(projected, ProjectedFaceData{Face: [3]Point2D{p1, p2, p3}, Color: face.Color, Distance: face.Distance})
+		}
+		projectedChunks[i] = projected
+	})
 
-			mu.Lock()
-			projectedFaces = append(projectedFaces, ProjectedFaceData{Face: [3]Point2D{p1, p2, p3}, Color: face.Color, Distance: face.Distance})
-			mu.Unlock()
-		}(face)
+	var projectedFaces []ProjectedFaceData
+	for _, projected := range projectedChunks {
+		projectedFaces = append(projectedFaces, projected...)
 	}
-	wg2d.Wait()
 
 	sort.Slice(projectedFaces, func(i, j int) bool {
 		return projectedFaces[i].Distance > projectedFaces[j].Distance
@@ -217,9 +312,36 @@ func (w *ThreeDWidget) render() image.Image {
 		drawFace(img, face, w.renderFaceOutlines, w.renderFaceColors)
 	}
 
+	w.drawBillboards(img, width, height)
+	w.drawLabels(img, width, height)
+	w.drawHUD(img, width, height)
+
 	return img
 }
 
+func (w *ThreeDWidget) drawBackground(img *image.RGBA, width, height Pixel) {
+	if w.skyHorizon == nil || w.skyZenith == nil {
+		draw.Draw(img, img.Bounds(), &image.Uniform{C: w.bgColor}, image.Point{}, draw.Src)
+		return
+	}
+	horizon := color.RGBAModel.Convert(w.skyHorizon).(color.RGBA)
+	zenith := color.RGBAModel.Convert(w.skyZenith).(color.RGBA)
+	lerp := func(a, b uint8, t float64) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	for y := 0; y < int(height); y++ {
+		ray := w.camera.ScreenRay(Point2D{X: width / 2, Y: Pixel(y)}, width, height)
+		t := math.Sqrt(math.Max(0, math.Min(1, float64(ray.Z))))
+		rowColor := color.RGBA{
+			R: lerp(horizon.R, zenith.R, t),
+			G: lerp(horizon.G, zenith.G, t),
+			B: lerp(horizon.B, zenith.B, t),
+			A: lerp(horizon.A, zenith.A, t),
+		}
+		draw.Draw(img, image.Rect(0, y, int(width), y+1), &image.Uniform{C: rowColor}, image.Point{}, draw.Src)
+	}
+}
+
 func (w *ThreeDWidget) Dragged(event *fyne.DragEvent) {
 	if controller, ok := w.camera.Controller.(DragController); ok {
 		controller.OnDrag(event.Dragged.DX, event.Dragged.DY)
@@ -238,15 +360,48 @@ func (w *ThreeDWidget) Scrolled(event *fyne.ScrollEvent) {
 	}
 }
 
+// Tapped focuses the widget so that it receives keyboard input for the camera controller and picks the tapped Object
+func (w *ThreeDWidget) Tapped(event *fyne.PointEvent) {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(w); c != nil {
+		c.Focus(w)
+	}
+	if w.onTapped != nil {
+		hit, ok := w.Pick(event.Position)
+		w.onTapped(event.Position, hit, ok)
+	}
+}
+
+func (w *ThreeDWidget) KeyDown(event *fyne.KeyEvent) {
+	if controller, ok := w.camera.Controller.(KeyController); ok {
+		controller.OnKeyDown(event.Name)
+	}
+}
+
+func (w *ThreeDWidget) KeyUp(event *fyne.KeyEvent) {
+	if controller, ok := w.camera.Controller.(KeyController); ok {
+		controller.OnKeyUp(event.Name)
+	}
+}
+
+func (w *ThreeDWidget) FocusGained() {}
+
+func (w *ThreeDWidget) FocusLost() {}
+
+func (w *ThreeDWidget) TypedRune(rune) {}
+
+func (w *ThreeDWidget) TypedKey(*fyne.KeyEvent) {}
+
 type threeDRenderer struct {
-	image *canvas.Image
+	widget *ThreeDWidget
+	image  *canvas.Image
 }
 
 // Layout resizes the widget to the given size
 func (r *threeDRenderer) Layout(size fyne.Size) {
 	r.image.Resize(size)
-	Width = Pixel(float64(size.Width) * resolutionFactor)
-	Height = Pixel(float64(size.Height) * resolutionFactor)
+	r.widget.mu.Lock()
+	r.widget.size = size
+	r.widget.mu.Unlock()
 }
 
 // MinSize returns the minimum size of the widget
@@ -304,9 +459,10 @@ func drawLine(img *image.RGBA, point1, point2 Point2D, lineColor color.Color) {
 		sy = Pixel(1)
 	}
 	err := dx - dy
+	width, height := Pixel(img.Bounds().Dx()), Pixel(img.Bounds().Dy())
 
 	for {
-		if x0 >= 0 && x0 < Width && y0 >= 0 && y0 < Height {
+		if x0 >= 0 && x0 < width && y0 >= 0 && y0 < height {
 			img.Set(int(x0), int(y0), lineColor)
 		}
 		if x0 == x1 && y0 == y1 {
@@ -335,12 +491,13 @@ func drawFilledTriangle(img *image.RGBA, p1, p2, p3 Point2D, fillColor color.Col
 		p2, p3 = p3, p2
 	}
 
+	width, height := Pixel(img.Bounds().Dx()), Pixel(img.Bounds().Dy())
 	drawHorizontalLine := func(y, x1, x2 Pixel, color color.Color) {
 		if x1 > x2 {
 			x1, x2 = x2, x1
 		}
 		for x := x1; x <= x2; x++ {
-			if y >= 0 && y < Height && x >= 0 && x < Width {
+			if y >= 0 && y < height && x >= 0 && x < width {
 				img.Set(int(x), int(y), color)
 			}
 		}
@@ -0,0 +1,124 @@
+package ThreeDView
+
+import (
+	. "FlightControl/ThreeDView/camera"
+	. "FlightControl/ThreeDView/object"
+	. "FlightControl/ThreeDView/types"
+	"image"
+	"image/color"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// benchmarkScene builds an 800x600 scene with a 3200 face ground plane and 40 round models, similar to the launch site
+func benchmarkScene() *ThreeDWidget {
+	w := NewOffscreenThreeDWidget(800, 600)
+	w.SetSkyGradient(color.RGBA{R: 180, G: 210, B: 240, A: 255}, color.RGBA{R: 40, G: 90, B: 180, A: 255})
+	NewPlane(4000, Point3D{}, Rotation3D{}, color.RGBA{G: 150, A: 255}, w, 40)
+	for i := 0; i < 40; i++ {
+		position := Point3D{X: Unit(i%8-4) * 300, Y: Unit(i/8-2) * 300, Z: 60}
+		if i%2 == 0 {
+			NewCylinder(position, Rotation3D{}, color.RGBA{R: 150, G: 150, B: 150, A: 255}, w, 60, 10)
+		} else {
+			NewCone(position, Rotation3D{}, color.RGBA{R: 200, G: 200, B: 200, A: 255}, w, 30, 10)
+		}
+	}
+	camera := NewCamera(Point3D{Y: -1800, Z: 700}, Rotation3D{})
+	camera.LookAt(Point3D{}, Point3D{Z: 1})
+	w.SetCamera(&camera)
+	return w
+}
+
+// getFacesGoroutinePerFace transforms the faces like Object.GetFaces did before the worker pool: one goroutine per face
+// that walks the hierarchy for every vertex
+func getFacesGoroutinePerFace(object *Object, cameraPosition Point3D) []FaceData {
+	faces := make([]FaceData, len(object.Faces))
+	var wg sync.WaitGroup
+	wg.Add(len(object.Faces))
+	for i, face := range object.Faces {
+		go func(i int, face FaceData) {
+			defer wg.Done()
+			face.Face[0] = object.ToWorld(face.Face[0])
+			face.Face[1] = object.ToWorld(face.Face[1])
+			face.Face[2] = object.ToWorld(face.Face[2])
+			face.Distance = face.Face.DistanceTo(cameraPosition)
+			faces[i] = face
+		}(i, face)
+	}
+	wg.Wait()
+	return faces
+}
+
+// renderGoroutinePerFace renders like the widget did before the worker pool: one goroutine per object and per face,
+// without culling whole objects or levels of detail
+func renderGoroutinePerFace(w *ThreeDWidget) image.Image {
+	width, height := w.GetWidth(), w.GetHeight()
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	w.drawBackground(img, width, height)
+
+	var faces []FaceData
+	var wg3d sync.WaitGroup
+	var mu3d sync.Mutex
+	wg3d.Add(len(w.objects))
+	for _, object := range w.objects {
+		go func(object *Object) {
+			defer wg3d.Done()
+			objectFaces := getFacesGoroutinePerFace(object, w.camera.Position)
+			mu3d.Lock()
+			for _, face := range objectFaces {
+				if w.camera.IsInFrustum(face.Face[0]) || w.camera.IsInFrustum(face.Face[1]) || w.camera.IsInFrustum(face.Face[2]) {
+					faces = append(faces, face)
+				}
+			}
+			mu3d.Unlock()
+		}(object)
+	}
+	wg3d.Wait()
+
+	var projectedFaces []ProjectedFaceData
+	var wg2d sync.WaitGroup
+	var mu2d sync.Mutex
+	wg2d.Add(len(faces))
+	for _, face := range faces {
+		go func(face FaceData) {
+			defer wg2d.Done()
+			p1 := w.camera.Project(face.Face[0], width, height)
+			p2 := w.camera.Project(face.Face[1], width, height)
+			p3 := w.camera.Project(face.Face[2], width, height)
+			if !p1.InBounds(0, 0, width, height) && !p2.InBounds(0, 0, width, height) && !p3.InBounds(0, 0, width, height) {
+				return
+			}
+			mu2d.Lock()
+			projectedFaces = append(projectedFaces, ProjectedFaceData{Face: [3]Point2D{p1, p2, p3}, Color: face.Color, Distance: face.Distance})
+			mu2d.Unlock()
+		}(face)
+	}
+	wg2d.Wait()
+
+	sort.Slice(projectedFaces, func(i, j int) bool {
+		return projectedFaces[i].Distance > projectedFaces[j].Distance
+	})
+	for _, face := range projectedFaces {
+		drawFace(img, face, w.renderFaceOutlines, w.renderFaceColors)
+	}
+	return img
+}
+
+func BenchmarkRender(b *testing.B) {
+	w := benchmarkScene()
+	w.RenderFrame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.RenderFrame()
+	}
+}
+
+func BenchmarkRenderGoroutinePerFace(b *testing.B) {
+	w := benchmarkScene()
+	renderGoroutinePerFace(w)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderGoroutinePerFace(w)
+	}
+}
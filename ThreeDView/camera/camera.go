@@ -24,6 +24,47 @@ func (camera *Camera) SetController(controller Controller) {
 	controller.setCamera(camera)
 }
 
+// LookAt rotates the camera so that it looks at the target and the up vector points upwards on the screen
+func (camera *Camera) LookAt(target Point3D, up Point3D) {
+	forward := DirectionVector{Point3D: target}
+	forward.Subtract(camera.Position)
+	if forward.Magnitude() == 0 {
+		return
+	}
+	forward.Normalize()
+
+	down := perpendicularDown(forward.Point3D, up)
+	if down.Magnitude() < 0.000001 {
+		// Looking straight along the up vector, any perpendicular direction can be used as down
+		down = perpendicularDown(forward.Point3D, Point3D{Y: -1})
+	}
+	down.Normalize()
+	right := down.Cross(forward.Point3D)
+
+	// The camera axes in world space are the rows of the world to camera rotation, RotationMatrix stores its transpose
+	rotationMatrix := RotationMatrix{
+		{float64(right.X), float64(down.X), float64(forward.X)},
+		{float64(right.Y), float64(down.Y), float64(forward.Y)},
+		{float64(right.Z), float64(down.Z), float64(forward.Z)},
+	}
+	camera.Rotation = rotationMatrix.ToRotation3D()
+}
+
+// GetForward returns the direction the camera is looking at in world space
+func (camera *Camera) GetForward() DirectionVector {
+	return camera.Rotation.ToDirectionVector()
+}
+
+// perpendicularDown returns the inverted up vector with the component along forward removed
+func perpendicularDown(forward Point3D, up Point3D) DirectionVector {
+	down := DirectionVector{Point3D: up}
+	down.Scale(-1)
+	projection := forward
+	projection.Scale(down.Dot(forward))
+	down.Subtract(projection)
+	return down
+}
+
 // Project projects a 3D point to a 2D point on the screen
 func (camera *Camera) Project(point Point3D, width, height Pixel) Point2D {
 	translatedPoint := point
@@ -45,17 +86,18 @@ func (camera *Camera) Project(point Point3D, width, height Pixel) Point2D {
 	return Point2D{X: Pixel(x2D), Y: Pixel(y2D)}
 }
 
-// UnProject un-projects a 2D point on the screen to a 3D point in world space
+// UnProject un-projects a 2D point on the screen to a 3D point in world space at the given depth in front of the camera
 func (camera *Camera) UnProject(point2d Point2D, distance Unit, width, height Pixel) Point3D {
 	fovRadians := camera.Fov.ToRadians()
 	halfWidth := float64(width) / 2
 	halfHeight := float64(height) / 2
+	// Project uses the same scale for both axes, derived from the width
 	scale := math.Tan(float64(fovRadians)/2) * float64(distance)
 
 	pointInCameraSpace := Point3D{
 		X: Unit((float64(point2d.X) - halfWidth) / halfWidth * scale),
-		Y: Unit((float64(point2d.Y) - halfHeight) / halfHeight * scale),
-		Z: -distance,
+		Y: Unit((float64(point2d.Y) - halfHeight) / halfWidth * scale),
+		Z: distance,
 	}
 
 	rotationMatrix := camera.Rotation.ToRotationMatrix()
@@ -65,6 +107,14 @@ func (camera *Camera) UnProject(point2d Point2D, distance Unit, width, height Pi
 	return pointInWorldSpace
 }
 
+// ScreenRay returns the direction of the ray from the camera position through a 2D point on the screen
+func (camera *Camera) ScreenRay(point2d Point2D, width, height Pixel) DirectionVector {
+	direction := DirectionVector{Point3D: camera.UnProject(point2d, 1, width, height)}
+	direction.Subtract(camera.Position)
+	direction.Normalize()
+	return direction
+}
+
 // IsInFrustum checks if a point is in the camera's frustum
 func (camera *Camera) IsInFrustum(point Point3D) bool {
 	translatedPoint := point
@@ -91,3 +141,34 @@ func (camera *Camera) IsInFrustum(point Point3D) bool {
 
 	return true
 }
+
+// IsSphereInFrustum checks if any part of a sphere is in the camera's frustum. It uses the same frustum as IsInFrustum
+func (camera *Camera) IsSphereInFrustum(center Point3D, radius Unit) bool {
+	translatedCenter := center
+	translatedCenter.Subtract(camera.Position)
+	translatedCenter.Rotate(Point3D{}, camera.Rotation)
+
+	if translatedCenter.Z+radius < Unit(0.1) {
+		return false
+	}
+
+	tanFovOver2 := Unit(math.Tan(float64(camera.Fov.ToRadians()) / 2))
+	// Distance of the center to the side planes, which are tilted by half the field of view
+	planeNormalLength := Unit(math.Sqrt(float64(1 + tanFovOver2*tanFovOver2)))
+	for _, offset := range []Unit{translatedCenter.X, -translatedCenter.X, translatedCenter.Y, -translatedCenter.Y} {
+		if (offset-translatedCenter.Z*tanFovOver2)/planeNormalLength > radius {
+			return false
+		}
+	}
+	return true
+}
+
+// ProjectedSize returns the approximate width in pixels of a sphere on the screen
+func (camera *Camera) ProjectedSize(center Point3D, radius Unit, width Pixel) Unit {
+	distance := center.DistanceTo(camera.Position)
+	if distance <= radius {
+		return Unit(math.Inf(1))
+	}
+	scale := Unit(float64(width) / (2 * math.Tan(float64(camera.Fov.ToRadians())/2)))
+	return 2 * radius * scale / distance
+}
@@ -1,8 +1,14 @@
 package camera
 
+import (
+	"fyne.io/fyne/v2"
+	"time"
+)
+
 // Controller is an interface for camera controllers to implement
 type Controller interface {
 	setCamera(*Camera)
+	Update()
 }
 
 // BaseController is a base controller for camera controllers
@@ -15,6 +21,15 @@ func (controller *BaseController) setCamera(camera *Camera) {
 	controller.camera = camera
 }
 
+// Update updates the camera. Controllers that move the camera on their own should override this and get called every tick
+func (controller *BaseController) Update() {}
+
+// TimedController is an interface for Controller whose movement depends on the time between two updates.
+// UpdateAt updates the camera as if the given time was now, e.g. to render frames at a fixed rate
+type TimedController interface {
+	UpdateAt(time.Time)
+}
+
 // DragController is an interface for Controller that supports dragging
 type DragController interface {
 	OnDrag(float32, float32)
@@ -25,3 +40,9 @@ type DragController interface {
 type ScrollController interface {
 	OnScroll(float32, float32)
 }
+
+// KeyController is an interface for Controller that supports keyboard input
+type KeyController interface {
+	OnKeyDown(fyne.KeyName)
+	OnKeyUp(fyne.KeyName)
+}
@@ -6,13 +6,25 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
+	"math"
+	"sync"
 	"time"
 )
 
+const defaultOrbitDistance = Unit(500)
+
+var worldUp = Point3D{Z: 1}
+
 type ObjectInterface interface {
 	GetPosition() Point3D
 }
 
+// VelocityObjectInterface is implemented by targets that know their own velocity in units per second
+type VelocityObjectInterface interface {
+	ObjectInterface
+	GetVelocity() Point3D
+}
+
 // OrbitController is a controller that allows the camera to orbit around a target Object
 type OrbitController struct {
 	BaseController
@@ -24,7 +36,7 @@ type OrbitController struct {
 
 // NewOrbitController creates a new OrbitController with the target Object
 func NewOrbitController(target ObjectInterface) *OrbitController {
-	return &OrbitController{target: target, distance: 500, rotation: Rotation3D{Pitch: 300}, controlsEnabled: true}
+	return &OrbitController{target: target, distance: defaultOrbitDistance, rotation: Rotation3D{Pitch: 300}, controlsEnabled: true}
 }
 
 func (controller *OrbitController) setCamera(camera *Camera) {
@@ -108,15 +120,7 @@ func (controller *OrbitController) pointAtTarget() {
 	if controller.camera == nil {
 		return
 	}
-	targetPosition := controller.target.GetPosition()
-	direction := DirectionVector{Point3D: targetPosition}
-	direction.Subtract(controller.camera.Position)
-	direction.Normalize()
-	rotation := direction.ToRotation()
-	controller.camera.Rotation.Roll = -rotation.Roll
-	controller.camera.Rotation.Pitch = -rotation.Pitch
-
-	controller.camera.Rotation.Yaw = controller.rotation.Yaw - 90
+	controller.camera.LookAt(controller.target.GetPosition(), worldUp)
 }
 
 // ManualController is a controller that allows the camera to be manually controlled. Useful for debugging
@@ -200,7 +204,7 @@ func (controller *ManualController) GetInfoLabel() *widget.Label {
 		ticker := time.NewTicker(time.Second / 30)
 		defer ticker.Stop()
 		for range ticker.C {
-			label.SetText(fmt.Sprintf("X: %.2f Y: %.2f Z: %.2f      Yaw: %d Pitch: %d Roll: %d",
+			label.SetText(fmt.Sprintf("X: %.2f Y: %.2f Z: %.2f      Yaw: %.2f Pitch: %.2f Roll: %.2f",
 				controller.camera.Position.X, controller.camera.Position.Y, controller.camera.Position.Z,
 				controller.camera.Rotation.Roll, controller.camera.Rotation.Pitch, controller.camera.Rotation.Yaw))
 			label.Refresh()
@@ -208,3 +212,294 @@ func (controller *ManualController) GetInfoLabel() *widget.Label {
 	}()
 	return label
 }
+
+// ChaseController is a controller that follows a target from behind along its direction of travel
+type ChaseController struct {
+	BaseController
+	target             ObjectInterface // The Object the camera is chasing
+	distance           Unit            // The distance of the camera behind the target
+	offset             Point3D         // Offset of the camera from the position behind the target in world space
+	smoothing          time.Duration   // The time it takes the camera to catch up with most of a change of the target
+	direction          DirectionVector // The smoothed direction of travel of the target
+	lastTargetPosition Point3D
+	lastUpdate         time.Time
+}
+
+// NewChaseController creates a new ChaseController with the target Object
+func NewChaseController(target ObjectInterface) *ChaseController {
+	return &ChaseController{
+		target:    target,
+		distance:  300,
+		offset:    Point3D{Y: 100},
+		smoothing: 300 * time.Millisecond,
+		direction: DirectionVector{Point3D: worldUp},
+	}
+}
+
+func (controller *ChaseController) setCamera(camera *Camera) {
+	controller.BaseController.camera = camera
+	controller.lastUpdate = time.Time{}
+	controller.Update()
+	// Only place the camera, the smoothing starts with the next update so UpdateAt can use its own clock
+	controller.lastUpdate = time.Time{}
+}
+
+// SetTarget sets the target Object for the camera to chase
+func (controller *ChaseController) SetTarget(target ObjectInterface) {
+	controller.target = target
+	controller.lastUpdate = time.Time{}
+}
+
+// SetDistance sets the distance of the camera behind the target Object
+func (controller *ChaseController) SetDistance(distance Unit) {
+	controller.distance = distance
+}
+
+// SetOffset sets the offset of the camera from the position behind the target in world space
+func (controller *ChaseController) SetOffset(offset Point3D) {
+	controller.offset = offset
+}
+
+// SetSmoothing sets how long the camera takes to catch up with changes of the target. 0 disables smoothing
+func (controller *ChaseController) SetSmoothing(smoothing time.Duration) {
+	controller.smoothing = smoothing
+}
+
+// OnScroll is called when the user scrolls the camera. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *ChaseController) OnScroll(_, y float32) {
+	controller.distance = Unit(math.Max(10, float64(controller.distance+Unit(y))))
+}
+
+// Update updates the position and rotation of the camera. Call after changing the targets position
+func (controller *ChaseController) Update() {
+	controller.UpdateAt(time.Now())
+}
+
+// UpdateAt updates the camera as if the given time was now. See TimedController
+func (controller *ChaseController) UpdateAt(now time.Time) {
+	if controller.camera == nil {
+		return
+	}
+	targetPosition := controller.target.GetPosition()
+	if controller.lastUpdate.IsZero() {
+		controller.lastUpdate = now
+		controller.lastTargetPosition = targetPosition
+		controller.camera.Position = controller.desiredPosition(targetPosition)
+		controller.camera.LookAt(targetPosition, worldUp)
+		return
+	}
+	factor := smoothingFactor(now.Sub(controller.lastUpdate), controller.smoothing)
+	controller.lastUpdate = now
+
+	travel := DirectionVector{Point3D: controller.travelDirection(targetPosition)}
+	controller.lastTargetPosition = targetPosition
+	if travel.Magnitude() > 0.000001 {
+		travel.Normalize()
+		travel.Subtract(controller.direction.Point3D)
+		travel.Scale(Unit(factor))
+		controller.direction.Add(travel.Point3D)
+		controller.direction.Normalize()
+	}
+
+	step := controller.desiredPosition(targetPosition)
+	step.Subtract(controller.camera.Position)
+	step.Scale(Unit(factor))
+	controller.camera.Position.Add(step)
+	controller.camera.LookAt(targetPosition, worldUp)
+}
+
+func (controller *ChaseController) travelDirection(targetPosition Point3D) Point3D {
+	if target, ok := controller.target.(VelocityObjectInterface); ok {
+		velocity := target.GetVelocity()
+		if velocity != (Point3D{}) {
+			return velocity
+		}
+	}
+	movement := targetPosition
+	movement.Subtract(controller.lastTargetPosition)
+	return movement
+}
+
+func (controller *ChaseController) desiredPosition(targetPosition Point3D) Point3D {
+	behind := controller.direction.Point3D
+	behind.Scale(-controller.distance)
+	position := targetPosition
+	position.Add(behind)
+	position.Add(controller.offset)
+	return position
+}
+
+// GroundObserverController is a controller that keeps the camera at a fixed position, e.g. next to the launch site,
+// and turns it to follow the target
+type GroundObserverController struct {
+	BaseController
+	target   ObjectInterface // The Object the camera is tracking
+	position Point3D         // The position of the observer in world space
+}
+
+// NewGroundObserverController creates a new GroundObserverController that tracks the target from the given position
+func NewGroundObserverController(target ObjectInterface, position Point3D) *GroundObserverController {
+	return &GroundObserverController{target: target, position: position}
+}
+
+func (controller *GroundObserverController) setCamera(camera *Camera) {
+	controller.BaseController.camera = camera
+	controller.Update()
+}
+
+// SetTarget sets the target Object for the camera to track
+func (controller *GroundObserverController) SetTarget(target ObjectInterface) {
+	controller.target = target
+}
+
+// SetPosition sets the position of the observer in world space
+func (controller *GroundObserverController) SetPosition(position Point3D) {
+	controller.position = position
+}
+
+// OnScroll is called when the user scrolls the camera. Zooms by changing the field of view. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *GroundObserverController) OnScroll(_, y float32) {
+	if controller.camera == nil {
+		return
+	}
+	controller.camera.Fov = Degrees(math.Min(120, math.Max(5, float64(controller.camera.Fov)-float64(y)/10)))
+}
+
+// Update updates the position and rotation of the camera. Call after changing the targets position
+func (controller *GroundObserverController) Update() {
+	if controller.camera == nil {
+		return
+	}
+	controller.camera.Position = controller.position
+	controller.camera.LookAt(controller.target.GetPosition(), worldUp)
+}
+
+// FreeFlyController is a controller that lets the user fly the camera around with the keyboard and look around by dragging.
+// W/S move forward and backward, A/D move sideways, E/Q move up and down. Scrolling changes the speed
+type FreeFlyController struct {
+	BaseController
+	yaw         Degrees // Rotation around the world up axis
+	pitch       Degrees // Angle above the horizon
+	speed       Unit    // Speed in units per second
+	pressedKeys map[fyne.KeyName]bool
+	lastUpdate  time.Time
+	mu          sync.Mutex
+}
+
+// NewFreeFlyController creates a new FreeFlyController
+func NewFreeFlyController() *FreeFlyController {
+	return &FreeFlyController{speed: 500, pressedKeys: map[fyne.KeyName]bool{}}
+}
+
+func (controller *FreeFlyController) setCamera(camera *Camera) {
+	controller.BaseController.camera = camera
+	forward := camera.GetForward()
+	controller.yaw = Radians(math.Atan2(float64(forward.Y), float64(forward.X))).ToDegrees()
+	controller.pitch = Radians(math.Asin(math.Max(-1, math.Min(1, float64(forward.Z))))).ToDegrees()
+	controller.lastUpdate = time.Time{}
+	controller.Update()
+}
+
+// SetSpeed sets the speed of the camera in units per second
+func (controller *FreeFlyController) SetSpeed(speed Unit) {
+	controller.speed = speed
+}
+
+// OnDrag is called when the user drags the camera. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *FreeFlyController) OnDrag(x, y float32) {
+	controller.mu.Lock()
+	defer controller.mu.Unlock()
+	controller.yaw -= Degrees(x) / 4
+	controller.pitch = Degrees(math.Max(-89, math.Min(89, float64(controller.pitch-Degrees(y)/4))))
+}
+
+// OnDragEnd is called when the user stops dragging the camera. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *FreeFlyController) OnDragEnd() {}
+
+// OnScroll is called when the user scrolls the camera. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *FreeFlyController) OnScroll(_, y float32) {
+	controller.speed = Unit(math.Max(10, float64(controller.speed)*math.Pow(1.1, float64(y)/10)))
+}
+
+// OnKeyDown is called when the user presses a key. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *FreeFlyController) OnKeyDown(key fyne.KeyName) {
+	controller.mu.Lock()
+	defer controller.mu.Unlock()
+	controller.pressedKeys[key] = true
+}
+
+// OnKeyUp is called when the user releases a key. DO NOT CALL THIS FUNCTION MANUALLY
+func (controller *FreeFlyController) OnKeyUp(key fyne.KeyName) {
+	controller.mu.Lock()
+	defer controller.mu.Unlock()
+	delete(controller.pressedKeys, key)
+}
+
+// Update moves the camera according to the pressed keys. Has to be called every tick
+func (controller *FreeFlyController) Update() {
+	controller.UpdateAt(time.Now())
+}
+
+// UpdateAt moves the camera as if the given time was now. See TimedController
+func (controller *FreeFlyController) UpdateAt(now time.Time) {
+	if controller.camera == nil {
+		return
+	}
+	controller.mu.Lock()
+	defer controller.mu.Unlock()
+
+	elapsed := time.Duration(0)
+	if !controller.lastUpdate.IsZero() {
+		elapsed = now.Sub(controller.lastUpdate)
+	}
+	controller.lastUpdate = now
+
+	yaw := float64(controller.yaw.ToRadians())
+	pitch := float64(controller.pitch.ToRadians())
+	forward := Point3D{
+		X: Unit(math.Cos(pitch) * math.Cos(yaw)),
+		Y: Unit(math.Cos(pitch) * math.Sin(yaw)),
+		Z: Unit(math.Sin(pitch)),
+	}
+	right := DirectionVector{Point3D: forward.Cross(worldUp)}
+	right.Normalize()
+
+	var movement Point3D
+	addMovement := func(direction Point3D, factor Unit) {
+		direction.Scale(factor)
+		movement.Add(direction)
+	}
+	if controller.pressedKeys[fyne.KeyW] || controller.pressedKeys[fyne.KeyUp] {
+		addMovement(forward, 1)
+	}
+	if controller.pressedKeys[fyne.KeyS] || controller.pressedKeys[fyne.KeyDown] {
+		addMovement(forward, -1)
+	}
+	if controller.pressedKeys[fyne.KeyD] || controller.pressedKeys[fyne.KeyRight] {
+		addMovement(right.Point3D, 1)
+	}
+	if controller.pressedKeys[fyne.KeyA] || controller.pressedKeys[fyne.KeyLeft] {
+		addMovement(right.Point3D, -1)
+	}
+	if controller.pressedKeys[fyne.KeyE] || controller.pressedKeys[fyne.KeySpace] {
+		addMovement(worldUp, 1)
+	}
+	if controller.pressedKeys[fyne.KeyQ] {
+		addMovement(worldUp, -1)
+	}
+	movement.Scale(controller.speed * Unit(elapsed.Seconds()))
+	controller.camera.Position.Add(movement)
+
+	target := controller.camera.Position
+	target.Add(forward)
+	controller.camera.LookAt(target, worldUp)
+}
+
+// smoothingFactor returns the fraction of the remaining distance that should be covered after elapsed time
+// when a change is smoothed over the given duration
+func smoothingFactor(elapsed, smoothing time.Duration) float64 {
+	if smoothing <= 0 {
+		return 1
+	}
+	return 1 - math.Exp(-elapsed.Seconds()/smoothing.Seconds())
+}
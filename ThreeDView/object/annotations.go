@@ -0,0 +1,137 @@
+package object
+
+import (
+	. "FlightControl/ThreeDView/camera"
+	. "FlightControl/ThreeDView/types"
+	"image"
+	"image/color"
+	"sync"
+)
+
+// Label is a text anchored to a point in 3D space. It is drawn on top of the scene and always faces the camera.
+// Once the Label is added to a widget, change Text, Position, Anchor and Hidden with the setters, the widget reads
+// them while rendering
+type Label struct {
+	Text       string          // The text of the Label. Can contain multiple lines
+	Position   Point3D         // Position of the Label in world space, or the offset from the Anchor if one is set
+	Anchor     ObjectInterface // Optional Object the Label follows
+	Color      color.Color     // The Color of the text
+	Background color.Color     // The Color behind the text. nil for no background
+	Hidden     bool            // Whether the Label is hidden
+	mu         sync.Mutex
+}
+
+// NewLabel creates a new Label at the given position in world space
+func NewLabel(text string, position Point3D, color color.Color, w ThreeDWidgetInterface) *Label {
+	label := &Label{
+		Text:       text,
+		Position:   position,
+		Color:      color,
+		Background: defaultLabelBackground,
+	}
+	w.AddLabel(label)
+	return label
+}
+
+// GetPosition returns the position of the Label in world space
+func (label *Label) GetPosition() Point3D {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	return anchoredPosition(label.Anchor, label.Position)
+}
+
+func (label *Label) GetText() string {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	return label.Text
+}
+
+func (label *Label) SetText(text string) {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	label.Text = text
+}
+
+func (label *Label) SetPosition(position Point3D) {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	label.Position = position
+}
+
+// SetAnchor makes the Label follow an Object. Its Position becomes the offset from the Object
+func (label *Label) SetAnchor(anchor ObjectInterface) {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	label.Anchor = anchor
+}
+
+func (label *Label) IsHidden() bool {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	return label.Hidden
+}
+
+func (label *Label) SetHidden(hidden bool) {
+	label.mu.Lock()
+	defer label.mu.Unlock()
+	label.Hidden = hidden
+}
+
+// NewAxisLabels creates labels for the X, Y and Z axis at length along each axis from the origin, in the colors of
+// the orientation object. If anchor is not nil the labels follow it
+func NewAxisLabels(origin Point3D, length Unit, anchor ObjectInterface, w ThreeDWidgetInterface) []*Label {
+	axes := []struct {
+		text      string
+		direction Point3D
+		color     color.Color
+	}{
+		{"X", Point3D{X: 1}, AxisColors[0]},
+		{"Y", Point3D{Y: 1}, AxisColors[1]},
+		{"Z", Point3D{Z: 1}, AxisColors[2]},
+	}
+	labels := make([]*Label, len(axes))
+	for i, axis := range axes {
+		position := axis.direction
+		position.Scale(length)
+		position.Add(origin)
+		labels[i] = &Label{Text: axis.text, Position: position, Anchor: anchor, Color: axis.color, Background: defaultLabelBackground}
+		w.AddLabel(labels[i])
+	}
+	return labels
+}
+
+// Billboard is an image anchored to a point in 3D space that always faces the camera and scales with the distance
+type Billboard struct {
+	Image    image.Image     // The Image of the Billboard
+	Position Point3D         // Position of the center of the Billboard in world space, or the offset from the Anchor if one is set
+	Anchor   ObjectInterface // Optional Object the Billboard follows
+	Size     Unit            // The height of the Billboard in world space. The width follows the aspect ratio of the Image
+	Hidden   bool            // Whether the Billboard is hidden
+}
+
+// NewBillboard creates a new Billboard at the given position in world space
+func NewBillboard(img image.Image, position Point3D, size Unit, w ThreeDWidgetInterface) *Billboard {
+	billboard := &Billboard{
+		Image:    img,
+		Position: position,
+		Size:     size,
+	}
+	w.AddBillboard(billboard)
+	return billboard
+}
+
+// GetPosition returns the position of the Billboard in world space
+func (billboard *Billboard) GetPosition() Point3D {
+	return anchoredPosition(billboard.Anchor, billboard.Position)
+}
+
+var defaultLabelBackground = color.RGBA{A: 160}
+
+func anchoredPosition(anchor ObjectInterface, position Point3D) Point3D {
+	if anchor == nil {
+		return position
+	}
+	anchorPosition := anchor.GetPosition()
+	anchorPosition.Add(position)
+	return anchorPosition
+}
@@ -94,6 +94,9 @@ func NewPlane(size Unit, position Point3D, rotation Rotation3D, color color.Colo
 	return &plane
 }
 
+// AxisColors are the colors of the X, Y and Z axis in the orientation object and the axis labels
+var AxisColors = [3]color.Color{color.RGBA{R: 255, A: 255}, color.RGBA{R: 255, G: 255, A: 255}, color.RGBA{B: 255, A: 255}}
+
 func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 	size := Unit(2)
 	thickness := size / 20
@@ -105,7 +108,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: size, Y: -thickness, Z: -thickness},
 				{X: 0, Y: thickness, Z: -thickness},
 			},
-			Color: color.RGBA{R: 255, A: 255},
+			Color: AxisColors[0],
 		},
 		{
 			Face: [3]Point3D{
@@ -113,7 +116,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: size, Y: thickness, Z: -thickness},
 				{X: 0, Y: thickness, Z: -thickness},
 			},
-			Color: color.RGBA{R: 255, A: 255},
+			Color: AxisColors[0],
 		},
 		{
 			Face: [3]Point3D{
@@ -121,7 +124,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: -thickness, Y: size, Z: -thickness},
 				{X: thickness, Y: 0, Z: -thickness},
 			},
-			Color: color.RGBA{R: 255, G: 255, A: 255},
+			Color: AxisColors[1],
 		},
 		{
 			Face: [3]Point3D{
@@ -129,7 +132,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: thickness, Y: size, Z: -thickness},
 				{X: thickness, Y: 0, Z: -thickness},
 			},
-			Color: color.RGBA{R: 255, G: 255, A: 255},
+			Color: AxisColors[1],
 		},
 		{
 			Face: [3]Point3D{
@@ -137,7 +140,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: -thickness, Y: -thickness, Z: size},
 				{X: thickness, Y: -thickness, Z: 0},
 			},
-			Color: color.RGBA{B: 255, A: 255},
+			Color: AxisColors[2],
 		},
 		{
 			Face: [3]Point3D{
@@ -145,7 +148,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 				{X: thickness, Y: -thickness, Z: size},
 				{X: thickness, Y: -thickness, Z: 0},
 			},
-			Color: color.RGBA{B: 255, A: 255},
+			Color: AxisColors[2],
 		},
 	}
 
@@ -159,6 +162,7 @@ func NewOrientationObject(w ThreeDWidgetInterface) *Object {
 		orientationObject.Position = w.GetCamera().UnProject(Point2D{X: 60, Y: 120}, 20, w.GetWidth(), w.GetHeight())
 	})
 	w.AddObject(&orientationObject)
+	NewAxisLabels(Point3D{}, size*1.2, &orientationObject, w)
 	return &orientationObject
 }
 
@@ -173,11 +177,43 @@ func NewEmpty(w ThreeDWidgetInterface, position Point3D) *Object {
 	return &empty
 }
 
+const (
+	lowDetailScreenSize     = Unit(40) // Round models are rendered with fewer segments below this size in pixels
+	minimalDetailScreenSize = Unit(12) // Round models are rendered with the fewest segments below this size in pixels
+)
+
 func NewCylinder(position Point3D, rotation Rotation3D, color color.Color, w ThreeDWidgetInterface, height, radius Unit) *Object {
+	cylinder := Object{
+		Faces:    cylinderFaces(height, radius, 20, color),
+		Position: position,
+		Rotation: rotation,
+		Widget:   w,
+	}
+	cylinder.AddLODLevel(cylinderFaces(height, radius, 60, color), lowDetailScreenSize)
+	cylinder.AddLODLevel(cylinderFaces(height, radius, 120, color), minimalDetailScreenSize)
+	w.AddObject(&cylinder)
+	return &cylinder
+}
+
+func NewCone(position Point3D, rotation Rotation3D, color color.Color, w ThreeDWidgetInterface, height, radius Unit) *Object {
+	cone := Object{
+		Faces:    coneFaces(height, radius, 20, color),
+		Position: position,
+		Rotation: rotation,
+		Widget:   w,
+	}
+	cone.AddLODLevel(coneFaces(height, radius, 60, color), lowDetailScreenSize)
+	cone.AddLODLevel(coneFaces(height, radius, 120, color), minimalDetailScreenSize)
+	w.AddObject(&cone)
+	return &cone
+}
+
+// cylinderFaces creates the side faces of a cylinder with one segment every segmentAngle degrees
+func cylinderFaces(height, radius Unit, segmentAngle int, color color.Color) []FaceData {
 	var faces []FaceData
-	for i := 0; i < 360; i += 20 {
+	for i := 0; i < 360; i += segmentAngle {
 		angle1 := Degrees(i).ToRadians()
-		angle2 := Degrees(i + 20).ToRadians()
+		angle2 := Degrees(i + segmentAngle).ToRadians()
 		p1 := Point3D{
 			X: radius * Unit(math.Cos(float64(angle1))),
 			Y: radius * Unit(math.Sin(float64(angle1))),
@@ -210,23 +246,15 @@ func NewCylinder(position Point3D, rotation Rotation3D, color color.Color, w Thr
 		}
 		faces = append(faces, sideFaces...)
 	}
-
-	cylinder := Object{
-		Faces:    faces,
-		Position: position,
-		Rotation: rotation,
-		Widget:   w,
-	}
-	w.AddObject(&cylinder)
-	return &cylinder
+	return faces
 }
 
-func NewCone(position Point3D, rotation Rotation3D, color color.Color, w ThreeDWidgetInterface, height, radius Unit) *Object {
+// coneFaces creates the side faces of a cone with one segment every segmentAngle degrees
+func coneFaces(height, radius Unit, segmentAngle int, color color.Color) []FaceData {
 	var faces []FaceData
-
-	for i := 0; i < 360; i += 20 {
+	for i := 0; i < 360; i += segmentAngle {
 		angle1 := Degrees(i).ToRadians()
-		angle2 := Degrees(i + 20).ToRadians()
+		angle2 := Degrees(i + segmentAngle).ToRadians()
 		p1 := Point3D{
 			X: 0,
 			Y: 0,
@@ -242,21 +270,10 @@ func NewCone(position Point3D, rotation Rotation3D, color color.Color, w ThreeDW
 			Y: radius * Unit(math.Sin(float64(angle2))),
 			Z: -height / 2,
 		}
-		sideFaces := []FaceData{
-			{
-				Face:  [3]Point3D{p1, p2, p3},
-				Color: color,
-			},
-		}
-		faces = append(faces, sideFaces...)
-	}
-
-	cone := Object{
-		Faces:    faces,
-		Position: position,
-		Rotation: rotation,
-		Widget:   w,
+		faces = append(faces, FaceData{
+			Face:  [3]Point3D{p1, p2, p3},
+			Color: color,
+		})
 	}
-	w.AddObject(&cone)
-	return &cone
+	return faces
 }
@@ -11,6 +11,8 @@ type ThreeDWidgetInterface interface {
 	GetCamera() *Camera
 	RegisterTickMethod(func())
 	AddObject(*Object)
+	AddLabel(*Label)
+	AddBillboard(*Billboard)
 	GetWidth() Pixel
 	GetHeight() Pixel
 }
@@ -29,36 +31,280 @@ type ProjectedFaceData struct {
 	Distance Unit        // The Distance of the un-projected Face from the camera in 3d world space
 }
 
-// Object represents a 3D shape in world space
+// Object represents a 3D shape in world space. Objects can be arranged in a hierarchy, in which case
+// the Position and Rotation of a child are relative to its parent
 type Object struct {
 	Faces    []FaceData            // Faces of the Object in local space
-	Rotation Rotation3D            // Rotation of the Object in world space
-	Position Point3D               // Position of the Object in world space
+	Rotation Rotation3D            // Rotation of the Object relative to its parent, or in world space if it has no parent
+	Position Point3D               // Position of the Object relative to its parent, or in world space if it has no parent
 	Widget   ThreeDWidgetInterface // The Widget the Object is in
+	parent   *Object               // The parent of the Object. nil if the Object is placed directly in world space
+	children []*Object             // The children of the Object
+	lods     []LODLevel            // Simplified versions of Faces, sorted by MaxScreenSize
+	bounds   boundingSphere        // Cached bounding sphere of Faces in local space
+	facesMu  sync.RWMutex          // Guards Faces, lods and bounds, which the render workers read while they change
+}
+
+// LODLevel is a simplified version of the faces of an Object that is rendered when the Object is small on the screen
+type LODLevel struct {
+	Faces         []FaceData // The faces of the level in local space
+	MaxScreenSize Unit       // The level is used when the bounding sphere of the Object is at most this many pixels wide
+}
+
+// Transform converts points from the local space of an Object to world space
+type Transform struct {
+	rotation RotationMatrix
+	position Point3D
+}
+
+// Apply converts a point from local space to world space
+func (transform *Transform) Apply(point Point3D) Point3D {
+	point = transform.rotation.ApplyRotationMatrix(point)
+	point.Add(transform.position)
+	return point
+}
+
+type boundingSphere struct {
+	center Point3D // Center of the sphere in local space
+	radius Unit    // Radius of the sphere
+	valid  bool    // Whether the sphere was computed for the current Faces
 }
 
 // GetFaces returns the faces of the shape in world space as FaceData
 func (object *Object) GetFaces() []FaceData {
-	faces := make([]FaceData, len(object.Faces))
-	var wg sync.WaitGroup
-	wg.Add(len(object.Faces))
-	for i, face := range object.Faces {
-		go func(i int, face FaceData) {
-			defer wg.Done()
-			face.Face.Rotate(Point3D{X: 0, Y: 0, Z: 0}, object.Rotation)
-			face.Face.Add(object.Position)
-			face.Distance = face.Face.DistanceTo(object.Widget.GetCamera().Position)
-			faces[i] = face
-		}(i, face)
-	}
-	wg.Wait()
-	return faces
+	object.facesMu.RLock()
+	faces := object.Faces
+	object.facesMu.RUnlock()
+	return TransformFaces(faces, object.GetWorldTransform(), object.Widget.GetCamera().Position)
+}
+
+// SetFaces replaces the faces of the Object. Use it instead of assigning Faces once the Object is rendered
+func (object *Object) SetFaces(faces []FaceData) {
+	object.facesMu.Lock()
+	defer object.facesMu.Unlock()
+	object.Faces = faces
+	object.bounds.valid = false
+}
+
+// appendFaces adds faces to the Object. Faces that were already returned to a renderer are never modified
+func (object *Object) appendFaces(faces ...FaceData) {
+	object.facesMu.Lock()
+	defer object.facesMu.Unlock()
+	object.Faces = append(object.Faces, faces...)
+	object.bounds.valid = false
+}
+
+// GetLODFaces returns the faces of the level of detail that fits the given size on the screen in local space.
+// Returns Faces if no simplified level is small enough
+func (object *Object) GetLODFaces(screenSize Unit) []FaceData {
+	object.facesMu.RLock()
+	defer object.facesMu.RUnlock()
+	for _, level := range object.lods {
+		if screenSize <= level.MaxScreenSize {
+			return level.Faces
+		}
+	}
+	return object.Faces
+}
+
+// AddLODLevel adds a simplified version of the faces that is rendered when the Object is at most maxScreenSize pixels wide
+func (object *Object) AddLODLevel(faces []FaceData, maxScreenSize Unit) {
+	object.facesMu.Lock()
+	defer object.facesMu.Unlock()
+	level := LODLevel{Faces: faces, MaxScreenSize: maxScreenSize}
+	index := len(object.lods)
+	for i, other := range object.lods {
+		if maxScreenSize < other.MaxScreenSize {
+			index = i
+			break
+		}
+	}
+	object.lods = append(object.lods[:index], append([]LODLevel{level}, object.lods[index:]...)...)
 }
 
+// TransformFaces converts faces from local space to world space and sets their distance to the camera
+func TransformFaces(faces []FaceData, transform Transform, cameraPosition Point3D) []FaceData {
+	transformed := make([]FaceData, len(faces))
+	for i, face := range faces {
+		face.Face[0] = transform.Apply(face.Face[0])
+		face.Face[1] = transform.Apply(face.Face[1])
+		face.Face[2] = transform.Apply(face.Face[2])
+		face.Distance = face.Face.DistanceTo(cameraPosition)
+		transformed[i] = face
+	}
+	return transformed
+}
+
+// GetWorldTransform returns the Transform from the local space of the Object to world space
+func (object *Object) GetWorldTransform() Transform {
+	return Transform{rotation: object.getWorldQuaternion().ToRotationMatrix(), position: object.GetWorldPosition()}
+}
+
+// GetBoundingSphere returns the center in world space and the radius of a sphere that contains all faces of the Object.
+// The sphere is cached until the faces are changed with SetFaces. Call UpdateBounds after changing Faces in another way
+func (object *Object) GetBoundingSphere() (Point3D, Unit) {
+	object.facesMu.RLock()
+	bounds := object.bounds
+	object.facesMu.RUnlock()
+	if !bounds.valid {
+		object.facesMu.Lock()
+		if !object.bounds.valid {
+			object.computeBounds()
+		}
+		bounds = object.bounds
+		object.facesMu.Unlock()
+	}
+	return object.ToWorld(bounds.center), bounds.radius
+}
+
+// UpdateBounds recomputes the bounding sphere of the Object
+func (object *Object) UpdateBounds() {
+	object.facesMu.Lock()
+	defer object.facesMu.Unlock()
+	object.computeBounds()
+}
+
+func (object *Object) computeBounds() {
+	object.bounds = boundingSphere{valid: true}
+	if len(object.Faces) == 0 {
+		return
+	}
+
+	minimum, maximum := object.Faces[0].Face[0], object.Faces[0].Face[0]
+	for _, face := range object.Faces {
+		for _, vertex := range face.Face {
+			minimum = Point3D{X: min(minimum.X, vertex.X), Y: min(minimum.Y, vertex.Y), Z: min(minimum.Z, vertex.Z)}
+			maximum = Point3D{X: max(maximum.X, vertex.X), Y: max(maximum.Y, vertex.Y), Z: max(maximum.Z, vertex.Z)}
+		}
+	}
+	center := Point3D{X: (minimum.X + maximum.X) / 2, Y: (minimum.Y + maximum.Y) / 2, Z: (minimum.Z + maximum.Z) / 2}
+	var radius Unit
+	for _, face := range object.Faces {
+		for _, vertex := range face.Face {
+			radius = max(radius, vertex.DistanceTo(center))
+		}
+	}
+	object.bounds.center = center
+	object.bounds.radius = radius
+}
+
+// GetPosition returns the position of the Object in world space
 func (object *Object) GetPosition() Point3D {
-	return object.Position
+	return object.GetWorldPosition()
 }
 
+// GetRotation returns the rotation of the Object in world space
 func (object *Object) GetRotation() Rotation3D {
-	return object.Rotation
+	return object.GetWorldRotation()
+}
+
+// GetWorldPosition returns the position of the Object in world space computed through the hierarchy
+func (object *Object) GetWorldPosition() Point3D {
+	if object.parent == nil {
+		return object.Position
+	}
+	return object.parent.ToWorld(object.Position)
+}
+
+// GetWorldRotation returns the rotation of the Object in world space computed through the hierarchy
+func (object *Object) GetWorldRotation() Rotation3D {
+	if object.parent == nil {
+		return object.Rotation
+	}
+	return object.getWorldQuaternion().ToRotation3D()
+}
+
+func (object *Object) getWorldQuaternion() Quaternion {
+	local := object.Rotation.ToQuaternion()
+	if object.parent == nil {
+		return local
+	}
+	return object.parent.getWorldQuaternion().Multiply(local)
+}
+
+// ToWorld converts a point from the local space of the Object to world space
+func (object *Object) ToWorld(point Point3D) Point3D {
+	point.Rotate(Point3D{}, object.Rotation)
+	point.Add(object.Position)
+	if object.parent == nil {
+		return point
+	}
+	return object.parent.ToWorld(point)
+}
+
+// ToLocal converts a point from world space to the local space of the Object
+func (object *Object) ToLocal(point Point3D) Point3D {
+	if object.parent != nil {
+		point = object.parent.ToLocal(point)
+	}
+	point.Subtract(object.Position)
+	point.RotateInverse(Point3D{}, object.Rotation)
+	return point
+}
+
+// GetParent returns the parent of the Object or nil if the Object is placed directly in world space
+func (object *Object) GetParent() *Object {
+	return object.parent
+}
+
+// GetChildren returns the direct children of the Object
+func (object *Object) GetChildren() []*Object {
+	return object.children
+}
+
+// AddChild makes the given Object a child of this Object. The Position and Rotation of the child are kept as they are
+// and are from now on relative to this Object. Use SetParent to keep the world transform instead
+func (object *Object) AddChild(child *Object) {
+	if child.parent == object || child == object || object.IsDescendantOf(child) {
+		return
+	}
+	if child.parent != nil {
+		child.parent.removeChild(child)
+	}
+	child.parent = object
+	object.children = append(object.children, child)
+}
+
+// SetParent moves the Object into the local space of the given parent while keeping its world transform.
+// Passing nil moves the Object back into world space. Parents that would create a cycle are ignored
+func (object *Object) SetParent(parent *Object) {
+	if parent == object.parent || parent == object || (parent != nil && parent.IsDescendantOf(object)) {
+		return
+	}
+	worldPosition := object.GetWorldPosition()
+	worldRotation := object.getWorldQuaternion()
+
+	if object.parent != nil {
+		object.parent.removeChild(object)
+	}
+	object.parent = parent
+
+	if parent == nil {
+		object.Position = worldPosition
+		object.Rotation = worldRotation.ToRotation3D()
+		return
+	}
+	parent.children = append(parent.children, object)
+
+	object.Position = parent.ToLocal(worldPosition)
+	object.Rotation = parent.getWorldQuaternion().Inverse().Multiply(worldRotation).ToRotation3D()
+}
+
+// IsDescendantOf returns true if the Object is somewhere below the given Object in the hierarchy
+func (object *Object) IsDescendantOf(ancestor *Object) bool {
+	for current := object.parent; current != nil; current = current.parent {
+		if current == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func (object *Object) removeChild(child *Object) {
+	for i, c := range object.children {
+		if c == child {
+			object.children = append(object.children[:i], object.children[i+1:]...)
+			return
+		}
+	}
 }
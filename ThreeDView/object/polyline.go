@@ -0,0 +1,127 @@
+package object
+
+import (
+	. "FlightControl/ThreeDView/types"
+	"image/color"
+	"sync"
+)
+
+// Polyline is an Object that renders a line through a list of points. Every segment is rendered as two crossed ribbons,
+// so the line is visible from every direction
+type Polyline struct {
+	*Object
+	points []Point3D     // The points of the line in local space
+	colors []color.Color // The color of the segment ending at the point with the same index
+	width  Unit          // The width of the ribbons
+	mu     sync.Mutex
+}
+
+// NewPolyline creates a new empty Polyline at the given position. Points are added in the local space of the Object
+func NewPolyline(position Point3D, width Unit, w ThreeDWidgetInterface) *Polyline {
+	polyline := &Polyline{
+		Object: &Object{
+			Faces:    nil,
+			Position: position,
+			Rotation: Rotation3D{},
+			Widget:   w,
+		},
+		width: width,
+	}
+	w.AddObject(polyline.Object)
+	return polyline
+}
+
+// AddPoint appends a point to the line. The segment leading to the point is rendered in the given color
+func (polyline *Polyline) AddPoint(point Point3D, color color.Color) {
+	polyline.mu.Lock()
+	defer polyline.mu.Unlock()
+	polyline.points = append(polyline.points, point)
+	polyline.colors = append(polyline.colors, color)
+	if len(polyline.points) < 2 {
+		return
+	}
+	from := polyline.points[len(polyline.points)-2]
+	polyline.appendFaces(ribbonFaces(from, point, polyline.width, color)...)
+}
+
+// SetPoints replaces all points of the line. colors has to have the same length as points
+func (polyline *Polyline) SetPoints(points []Point3D, colors []color.Color) {
+	polyline.mu.Lock()
+	defer polyline.mu.Unlock()
+	polyline.points = append([]Point3D(nil), points...)
+	polyline.colors = append([]color.Color(nil), colors...)
+	polyline.rebuildFaces()
+}
+
+// GetPoints returns a copy of the points of the line in local space
+func (polyline *Polyline) GetPoints() []Point3D {
+	polyline.mu.Lock()
+	defer polyline.mu.Unlock()
+	return append([]Point3D(nil), polyline.points...)
+}
+
+// GetLastPoint returns the last point of the line in local space and false if the line is empty
+func (polyline *Polyline) GetLastPoint() (Point3D, bool) {
+	polyline.mu.Lock()
+	defer polyline.mu.Unlock()
+	if len(polyline.points) == 0 {
+		return Point3D{}, false
+	}
+	return polyline.points[len(polyline.points)-1], true
+}
+
+// Clear removes all points from the line
+func (polyline *Polyline) Clear() {
+	polyline.SetPoints(nil, nil)
+}
+
+// SetWidth sets the width of the ribbons the line is rendered with
+func (polyline *Polyline) SetWidth(width Unit) {
+	polyline.mu.Lock()
+	defer polyline.mu.Unlock()
+	polyline.width = width
+	polyline.rebuildFaces()
+}
+
+func (polyline *Polyline) rebuildFaces() {
+	var faces []FaceData
+	for i := 1; i < len(polyline.points); i++ {
+		faces = append(faces, ribbonFaces(polyline.points[i-1], polyline.points[i], polyline.width, polyline.colors[i])...)
+	}
+	polyline.SetFaces(faces)
+}
+
+// ribbonFaces creates the faces of two crossed ribbons from one point to another
+func ribbonFaces(from, to Point3D, width Unit, color color.Color) []FaceData {
+	direction := DirectionVector{Point3D: to}
+	direction.Subtract(from)
+	if direction.Magnitude() == 0 {
+		return nil
+	}
+	direction.Normalize()
+
+	side := DirectionVector{Point3D: direction.Cross(Point3D{Z: 1})}
+	if side.Magnitude() < 0.001 {
+		side = DirectionVector{Point3D: Point3D{X: 1}}
+	}
+	side.Normalize()
+	up := DirectionVector{Point3D: direction.Cross(side.Point3D)}
+	up.Normalize()
+
+	side.Scale(width / 2)
+	up.Scale(width / 2)
+
+	var faces []FaceData
+	for _, offset := range []Point3D{side.Point3D, up.Point3D} {
+		p1, p2, p3, p4 := from, from, to, to
+		p1.Add(offset)
+		p2.Subtract(offset)
+		p3.Subtract(offset)
+		p4.Add(offset)
+		faces = append(faces,
+			FaceData{Face: Face{p1, p2, p3}, Color: color},
+			FaceData{Face: Face{p1, p3, p4}, Color: color},
+		)
+	}
+	return faces
+}
@@ -0,0 +1,184 @@
+package object
+
+import (
+	. "FlightControl/ThreeDView/types"
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Terrain is an Object built from a grid of elevations. The grid is centered on the position of the Object
+type Terrain struct {
+	*Object
+	heights  [][]Unit // The elevation of every grid point. heights[row][column]
+	cellSize Unit     // The distance between two neighbouring grid points
+}
+
+// NewTerrain creates a new Terrain from a grid of elevations. Faces are colored by their elevation between low and high
+func NewTerrain(heights [][]Unit, cellSize Unit, position Point3D, low, high color.Color, w ThreeDWidgetInterface) (*Terrain, error) {
+	if len(heights) < 2 || len(heights[0]) < 2 {
+		return nil, errors.New("terrain needs at least 2x2 elevation points")
+	}
+	for _, row := range heights {
+		if len(row) != len(heights[0]) {
+			return nil, errors.New("all rows of the elevation grid need the same length")
+		}
+	}
+	terrain := &Terrain{
+		Object: &Object{
+			Position: position,
+			Rotation: Rotation3D{},
+			Widget:   w,
+		},
+		heights:  heights,
+		cellSize: cellSize,
+	}
+	terrain.buildFaces(low, high)
+	w.AddObject(terrain.Object)
+	return terrain, nil
+}
+
+// NewFlatTerrain creates a flat Terrain of the given size with resolution cells per side
+func NewFlatTerrain(size Unit, resolution int, position Point3D, color color.Color, w ThreeDWidgetInterface) *Terrain {
+	heights := make([][]Unit, resolution+1)
+	for i := range heights {
+		heights[i] = make([]Unit, resolution+1)
+	}
+	terrain, _ := NewTerrain(heights, size/Unit(resolution), position, color, color, w)
+	return terrain
+}
+
+// HeightAt returns the elevation of the Terrain at a point in world space, interpolated between the grid points.
+// Points outside the Terrain return 0
+func (terrain *Terrain) HeightAt(x, y Unit) Unit {
+	local := terrain.ToLocal(Point3D{X: x, Y: y})
+	column := float64((local.X + terrain.halfWidth()) / terrain.cellSize)
+	row := float64((local.Y + terrain.halfDepth()) / terrain.cellSize)
+	if column < 0 || row < 0 || column > float64(len(terrain.heights[0])-1) || row > float64(len(terrain.heights)-1) {
+		return 0
+	}
+	column0, row0 := int(column), int(row)
+	column1, row1 := min(column0+1, len(terrain.heights[0])-1), min(row0+1, len(terrain.heights)-1)
+	fx, fy := Unit(column-float64(column0)), Unit(row-float64(row0))
+	top := terrain.heights[row0][column0]*(1-fx) + terrain.heights[row0][column1]*fx
+	bottom := terrain.heights[row1][column0]*(1-fx) + terrain.heights[row1][column1]*fx
+	return terrain.ToWorld(Point3D{Z: top*(1-fy) + bottom*fy}).Z
+}
+
+// GetSize returns the width and depth of the Terrain
+func (terrain *Terrain) GetSize() (Unit, Unit) {
+	return terrain.halfWidth() * 2, terrain.halfDepth() * 2
+}
+
+func (terrain *Terrain) halfWidth() Unit {
+	return Unit(len(terrain.heights[0])-1) * terrain.cellSize / 2
+}
+
+func (terrain *Terrain) halfDepth() Unit {
+	return Unit(len(terrain.heights)-1) * terrain.cellSize / 2
+}
+
+func (terrain *Terrain) buildFaces(low, high color.Color) {
+	minHeight, maxHeight := Unit(math.Inf(1)), Unit(math.Inf(-1))
+	for _, row := range terrain.heights {
+		for _, height := range row {
+			minHeight = min(minHeight, height)
+			maxHeight = max(maxHeight, height)
+		}
+	}
+
+	vertex := func(row, column int) Point3D {
+		return Point3D{
+			X: Unit(column)*terrain.cellSize - terrain.halfWidth(),
+			Y: Unit(row)*terrain.cellSize - terrain.halfDepth(),
+			Z: terrain.heights[row][column],
+		}
+	}
+	faceColor := func(face Face) color.Color {
+		if maxHeight == minHeight {
+			return low
+		}
+		height := (face[0].Z + face[1].Z + face[2].Z) / 3
+		return lerpColor(low, high, float64((height-minHeight)/(maxHeight-minHeight)))
+	}
+
+	var faces []FaceData
+	for row := 0; row < len(terrain.heights)-1; row++ {
+		for column := 0; column < len(terrain.heights[row])-1; column++ {
+			topLeft := vertex(row, column)
+			topRight := vertex(row, column+1)
+			bottomLeft := vertex(row+1, column)
+			bottomRight := vertex(row+1, column+1)
+			for _, face := range []Face{{topLeft, topRight, bottomRight}, {topLeft, bottomRight, bottomLeft}} {
+				faces = append(faces, FaceData{Face: face, Color: faceColor(face)})
+			}
+		}
+	}
+	terrain.SetFaces(faces)
+}
+
+// LoadHeightmapPNG reads a grayscale image as an elevation grid. Black is an elevation of 0 and white is maxHeight.
+// The image is sampled down so that neither side of the grid has more than maxResolution cells
+func LoadHeightmapPNG(reader io.Reader, maxHeight Unit, maxResolution int) ([][]Unit, error) {
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode heightmap: %w", err)
+	}
+	bounds := img.Bounds()
+	step := max(1, (max(bounds.Dx(), bounds.Dy())+maxResolution-1)/maxResolution)
+
+	var heights [][]Unit
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		var row []Unit
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			gray := color.Gray16Model.Convert(img.At(x, y)).(color.Gray16)
+			row = append(row, Unit(gray.Y)/math.MaxUint16*maxHeight)
+		}
+		heights = append(heights, row)
+	}
+	return heights, nil
+}
+
+// LoadElevationGrid reads an elevation grid from a text file with one row of whitespace or comma separated
+// elevations per line. Empty lines and lines starting with # are ignored
+func LoadElevationGrid(reader io.Reader) ([][]Unit, error) {
+	var heights [][]Unit
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var row []Unit
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			height, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid elevation %q in line %d", field, line)
+			}
+			row = append(row, Unit(height))
+		}
+		heights = append(heights, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return heights, nil
+}
+
+func lerpColor(from, to color.Color, t float64) color.Color {
+	r1, g1, b1, a1 := from.RGBA()
+	r2, g2, b2, a2 := to.RGBA()
+	lerp := func(a, b uint32) uint8 {
+		return uint8((float64(a) + (float64(b)-float64(a))*t) / 257)
+	}
+	return color.RGBA{R: lerp(r1, r2), G: lerp(g1, g2), B: lerp(b1, b2), A: lerp(a1, a2)}
+}
@@ -0,0 +1,217 @@
+package ThreeDView
+
+import (
+	. "FlightControl/ThreeDView/object"
+	. "FlightControl/ThreeDView/types"
+	"fmt"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HUDAnchor is the corner of the widget a HUD element is placed in
+type HUDAnchor int
+
+const (
+	HUDTopLeft HUDAnchor = iota
+	HUDTopRight
+	HUDBottomLeft
+	HUDBottomRight
+)
+
+const (
+	hudMargin  = 4
+	textMargin = 2
+	markerSize = 2
+)
+
+var hudBackground = color.RGBA{A: 120}
+
+type hudElement struct {
+	anchor HUDAnchor
+	text   func() string
+}
+
+// AddLabel adds a Label to the widget. This should be called in the method that creates the Label
+func (w *ThreeDWidget) AddLabel(label *Label) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.labels = append(w.labels, label)
+}
+
+// RemoveLabel removes a Label from the widget
+func (w *ThreeDWidget) RemoveLabel(label *Label) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, l := range w.labels {
+		if l == label {
+			w.labels = append(w.labels[:i:i], w.labels[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddBillboard adds a Billboard to the widget. This should be called in the method that creates the Billboard
+func (w *ThreeDWidget) AddBillboard(billboard *Billboard) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.billboards = append(w.billboards, billboard)
+}
+
+// RemoveBillboard removes a Billboard from the widget
+func (w *ThreeDWidget) RemoveBillboard(billboard *Billboard) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, b := range w.billboards {
+		if b == billboard {
+			w.billboards = append(w.billboards[:i:i], w.billboards[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddHUDElement adds a line of text to the HUD in the given corner. The text function is called every frame
+func (w *ThreeDWidget) AddHUDElement(anchor HUDAnchor, text func() string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hudElements = append(w.hudElements, hudElement{anchor: anchor, text: text})
+}
+
+// SetShowFPS sets whether the measured frames per second are shown in the top right corner of the HUD
+func (w *ThreeDWidget) SetShowFPS(show bool) {
+	w.showFPS = show
+}
+
+// GetFPS returns the measured frames per second of the widget
+func (w *ThreeDWidget) GetFPS() float64 {
+	return w.fps
+}
+
+func (w *ThreeDWidget) drawBillboards(img *image.RGBA, width, height Pixel) {
+	type projectedBillboard struct {
+		billboard *Billboard
+		center    Point2D
+		distance  Unit
+	}
+	w.mu.Lock()
+	billboards := slices.Clone(w.billboards)
+	w.mu.Unlock()
+
+	var projected []projectedBillboard
+	for _, billboard := range billboards {
+		if billboard.Hidden || billboard.Image == nil {
+			continue
+		}
+		position := billboard.GetPosition()
+		if !w.camera.IsInFrustum(position) {
+			continue
+		}
+		projected = append(projected, projectedBillboard{
+			billboard: billboard,
+			center:    w.camera.Project(position, width, height),
+			distance:  position.DistanceTo(w.camera.Position),
+		})
+	}
+	sort.Slice(projected, func(i, j int) bool {
+		return projected[i].distance > projected[j].distance
+	})
+
+	scale := float64(width) / (2 * math.Tan(float64(w.camera.Fov.ToRadians())/2))
+	for _, p := range projected {
+		bounds := p.billboard.Image.Bounds()
+		pixelHeight := float64(p.billboard.Size) * scale / math.Max(float64(p.distance), 0.0001)
+		pixelWidth := pixelHeight * float64(bounds.Dx()) / float64(bounds.Dy())
+		if pixelHeight < 1 || pixelWidth < 1 || pixelHeight > float64(height)*4 {
+			continue
+		}
+		rect := image.Rect(
+			int(float64(p.center.X)-pixelWidth/2),
+			int(float64(p.center.Y)-pixelHeight/2),
+			int(float64(p.center.X)+pixelWidth/2),
+			int(float64(p.center.Y)+pixelHeight/2),
+		)
+		xdraw.ApproxBiLinear.Scale(img, rect, p.billboard.Image, bounds, xdraw.Over, nil)
+	}
+}
+
+func (w *ThreeDWidget) drawLabels(img *image.RGBA, width, height Pixel) {
+	w.mu.Lock()
+	labels := slices.Clone(w.labels)
+	w.mu.Unlock()
+
+	for _, label := range labels {
+		text := label.GetText()
+		if label.IsHidden() || text == "" {
+			continue
+		}
+		position := label.GetPosition()
+		if !w.camera.IsInFrustum(position) {
+			continue
+		}
+		point := w.camera.Project(position, width, height)
+		draw.Draw(img, image.Rect(int(point.X)-markerSize, int(point.Y)-markerSize, int(point.X)+markerSize+1, int(point.Y)+markerSize+1), &image.Uniform{C: label.Color}, image.Point{}, draw.Src)
+		drawText(img, text, image.Point{X: int(point.X) + markerSize + textMargin, Y: int(point.Y) - textHeight(text)/2}, label.Color, label.Background)
+	}
+}
+
+func (w *ThreeDWidget) drawHUD(img *image.RGBA, width, height Pixel) {
+	// The text functions run without the lock, so they can use the widget
+	w.mu.Lock()
+	hudElements := slices.Clone(w.hudElements)
+	w.mu.Unlock()
+
+	corners := map[HUDAnchor][]string{}
+	for _, element := range hudElements {
+		corners[element.anchor] = append(corners[element.anchor], element.text())
+	}
+	if w.showFPS {
+		corners[HUDTopRight] = append(corners[HUDTopRight], fmt.Sprintf("FPS: %.0f", w.fps))
+	}
+
+	for anchor, lines := range corners {
+		text := strings.Join(lines, "\n")
+		textWidth, textHeight := textWidth(text), textHeight(text)
+		origin := image.Point{X: hudMargin, Y: hudMargin}
+		if anchor == HUDTopRight || anchor == HUDBottomRight {
+			origin.X = int(width) - hudMargin - textWidth
+		}
+		if anchor == HUDBottomLeft || anchor == HUDBottomRight {
+			origin.Y = int(height) - hudMargin - textHeight
+		}
+		drawText(img, text, origin, color.White, hudBackground)
+	}
+}
+
+// drawText draws text with its top left corner at origin. If background is not nil a box is drawn behind the text
+func drawText(img *image.RGBA, text string, origin image.Point, textColor color.Color, background color.Color) {
+	face := basicfont.Face7x13
+	if background != nil {
+		box := image.Rect(origin.X-textMargin, origin.Y-textMargin, origin.X+textWidth(text)+textMargin, origin.Y+textHeight(text)+textMargin)
+		draw.Draw(img, box, &image.Uniform{C: background}, image.Point{}, draw.Over)
+	}
+	drawer := font.Drawer{Dst: img, Src: &image.Uniform{C: textColor}, Face: face}
+	for i, line := range strings.Split(text, "\n") {
+		drawer.Dot = fixed.P(origin.X, origin.Y+face.Ascent+i*face.Height)
+		drawer.DrawString(line)
+	}
+}
+
+func textWidth(text string) int {
+	width := 0
+	for _, line := range strings.Split(text, "\n") {
+		width = max(width, font.MeasureString(basicfont.Face7x13, line).Ceil())
+	}
+	return width
+}
+
+func textHeight(text string) int {
+	return len(strings.Split(text, "\n")) * basicfont.Face7x13.Height
+}
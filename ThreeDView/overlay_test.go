@@ -0,0 +1,44 @@
+package ThreeDView
+
+import (
+	. "FlightControl/ThreeDView/camera"
+	. "FlightControl/ThreeDView/object"
+	. "FlightControl/ThreeDView/types"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestOverlayConcurrentUpdates changes the overlay while frames are rendered. Run with -race to check the locking
+func TestOverlayConcurrentUpdates(t *testing.T) {
+	w := NewOffscreenThreeDWidget(200, 150)
+	camera := NewCamera(Point3D{Y: -1000}, Rotation3D{})
+	camera.LookAt(Point3D{}, Point3D{Z: 1})
+	w.SetCamera(&camera)
+	label := NewLabel("", Point3D{}, color.White, w)
+	NewAxisLabels(Point3D{}, 100, nil, w)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			label.SetText(fmt.Sprintf("Apogee %d m", i))
+			label.SetPosition(Point3D{Z: Unit(i)})
+			label.SetHidden(i%2 == 0)
+			removed := NewLabel("Removed", Point3D{X: Unit(i)}, color.White, w)
+			w.RemoveLabel(removed)
+			billboard := NewBillboard(image.NewRGBA(image.Rect(0, 0, 2, 2)), Point3D{}, 10, w)
+			w.RemoveBillboard(billboard)
+			w.AddHUDElement(HUDBottomRight, func() string { return "HUD" })
+		}
+	}()
+	for rendering := true; rendering; {
+		select {
+		case <-done:
+			rendering = false
+		default:
+		}
+		w.RenderFrame()
+	}
+}
@@ -0,0 +1,99 @@
+package ThreeDView
+
+import (
+	. "FlightControl/ThreeDView/object"
+	. "FlightControl/ThreeDView/types"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"math"
+)
+
+// Hit is the result of casting a ray from the camera into the scene
+type Hit struct {
+	Object   *Object // The Object that was hit
+	Position Point3D // The position in world space where the ray hit the Object
+	Distance Unit    // The distance from the camera to the hit position
+}
+
+// Pick casts a ray from the camera through a position on the widget and returns the closest Object it hits.
+// Returns false if no Object was hit
+func (w *ThreeDWidget) Pick(position fyne.Position) (Hit, bool) {
+	factor := w.getResolutionFactor()
+	point := Point2D{X: Pixel(float64(position.X) * factor), Y: Pixel(float64(position.Y) * factor)}
+	origin := w.camera.Position
+	direction := w.camera.ScreenRay(point, w.GetWidth(), w.GetHeight())
+
+	closest := Hit{Distance: Unit(math.Inf(1))}
+	for _, object := range w.objects {
+		if !rayHitsSphere(origin, direction, object) {
+			continue
+		}
+		for _, face := range object.GetFaces() {
+			distance, ok := face.Face.IntersectRay(origin, direction)
+			if !ok || distance >= closest.Distance {
+				continue
+			}
+			hitPosition := direction.Point3D
+			hitPosition.Scale(distance)
+			hitPosition.Add(origin)
+			closest = Hit{Object: object, Position: hitPosition, Distance: distance}
+		}
+	}
+	return closest, closest.Object != nil
+}
+
+// ScreenPosition returns the position of a point in world space on the widget. Returns false if the point is not in view
+func (w *ThreeDWidget) ScreenPosition(point Point3D) (fyne.Position, bool) {
+	if !w.camera.IsInFrustum(point) {
+		return fyne.Position{}, false
+	}
+	projected := w.camera.Project(point, w.GetWidth(), w.GetHeight())
+	factor := w.getResolutionFactor()
+	return fyne.NewPos(float32(float64(projected.X)/factor), float32(float64(projected.Y)/factor)), true
+}
+
+// SetOnTapped sets the function that is called with the picked Object when the user taps the scene.
+// ok is false if the tap did not hit an Object
+func (w *ThreeDWidget) SetOnTapped(onTapped func(position fyne.Position, hit Hit, ok bool)) {
+	w.onTapped = onTapped
+}
+
+// SetOnHovered sets the function that is called with the picked Object when the mouse moves over the scene.
+// ok is false if no Object is under the mouse
+func (w *ThreeDWidget) SetOnHovered(onHovered func(position fyne.Position, hit Hit, ok bool)) {
+	w.onHovered = onHovered
+}
+
+func (w *ThreeDWidget) MouseIn(event *desktop.MouseEvent) {
+	w.MouseMoved(event)
+}
+
+func (w *ThreeDWidget) MouseMoved(event *desktop.MouseEvent) {
+	if w.onHovered == nil {
+		return
+	}
+	hit, ok := w.Pick(event.Position)
+	w.onHovered(event.Position, hit, ok)
+}
+
+func (w *ThreeDWidget) MouseOut() {
+	if w.onHovered == nil {
+		return
+	}
+	w.onHovered(fyne.Position{}, Hit{}, false)
+}
+
+// rayHitsSphere checks if a ray passes through the bounding sphere of an Object
+func rayHitsSphere(origin Point3D, direction DirectionVector, object *Object) bool {
+	center, radius := object.GetBoundingSphere()
+	if radius == 0 {
+		return false
+	}
+	toCenter := center
+	toCenter.Subtract(origin)
+	along := toCenter.Dot(direction.Point3D)
+	if along < -radius {
+		return false
+	}
+	return toCenter.Dot(toCenter)-along*along <= radius*radius
+}
@@ -0,0 +1,64 @@
+package ThreeDView
+
+import (
+	. "FlightControl/ThreeDView/camera"
+	. "FlightControl/ThreeDView/object"
+	. "FlightControl/ThreeDView/types"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// pickingScene builds a widget that is laid out at size and shows a cube at the origin straight in front of the camera
+func pickingScene(size fyne.Size, resolutionFactor float64) *ThreeDWidget {
+	w := NewOffscreenThreeDWidget(0, 0)
+	w.SetResolutionFactor(resolutionFactor)
+	NewCube(100, Point3D{}, Rotation3D{}, color.White, w)
+	camera := NewCamera(Point3D{Y: -1000}, Rotation3D{})
+	camera.LookAt(Point3D{}, Point3D{Z: 1})
+	w.SetCamera(&camera)
+	renderer := &threeDRenderer{widget: w, image: canvas.NewImageFromImage(nil)}
+	renderer.Layout(size)
+	return w
+}
+
+func TestPickPerWidget(t *testing.T) {
+	test.NewTempApp(t)
+	tests := []struct {
+		name             string
+		size             fyne.Size
+		resolutionFactor float64
+	}{
+		{"wide", fyne.NewSize(400, 300), 2},
+		{"tall", fyne.NewSize(100, 900), 0.5},
+	}
+	// Lay out all widgets first, so each one has to use its own size and not the one laid out last
+	widgets := make([]*ThreeDWidget, len(tests))
+	for i, test := range tests {
+		widgets[i] = pickingScene(test.size, test.resolutionFactor)
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := widgets[i]
+			expectedWidth := Pixel(float64(test.size.Width) * test.resolutionFactor)
+			if w.GetWidth() != expectedWidth {
+				t.Errorf("width %v, expected %v", w.GetWidth(), expectedWidth)
+			}
+
+			center := fyne.NewPos(test.size.Width/2, test.size.Height/2)
+			position, ok := w.ScreenPosition(Point3D{})
+			if !ok || math.Abs(float64(position.X-center.X)) > 1 || math.Abs(float64(position.Y-center.Y)) > 1 {
+				t.Errorf("origin is at %v on the widget, expected %v", position, center)
+			}
+			if _, ok := w.Pick(center); !ok {
+				t.Error("the cube in the center wasn't picked")
+			}
+			if hit, ok := w.Pick(fyne.NewPos(0, 0)); ok {
+				t.Errorf("picked %v in the corner", hit)
+			}
+		})
+	}
+}
@@ -31,3 +31,36 @@ func (face *Face) Add(other Point3D) {
 func (face *Face) DistanceTo(point Point3D) Unit {
 	return (face[0].DistanceTo(point) + face[1].DistanceTo(point) + face[2].DistanceTo(point)) / 3
 }
+
+// IntersectRay returns the distance along the ray from origin in direction to the face, and false if the ray misses the face.
+// direction has to be normalized
+func (face *Face) IntersectRay(origin Point3D, direction DirectionVector) (Unit, bool) {
+	const epsilon = 1e-9
+	edge1 := face[1]
+	edge1.Subtract(face[0])
+	edge2 := face[2]
+	edge2.Subtract(face[0])
+
+	h := direction.Cross(edge2)
+	a := edge1.Dot(h)
+	if a > -epsilon && a < epsilon {
+		return 0, false
+	}
+	f := 1 / a
+	s := origin
+	s.Subtract(face[0])
+	u := f * s.Dot(h)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := s.Cross(edge1)
+	v := f * direction.Dot(q)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t := f * edge2.Dot(q)
+	if t <= epsilon {
+		return 0, false
+	}
+	return t, true
+}
@@ -49,6 +49,13 @@ func (point *Point3D) Rotate(pivot Point3D, rotation Rotation3D) {
 	point.RotateZ(pivot, rotation.Yaw)
 }
 
+// RotateInverse rotates the point around a pivot point by the inverse of the given rotation
+func (point *Point3D) RotateInverse(pivot Point3D, rotation Rotation3D) {
+	point.RotateZ(pivot, -rotation.Yaw)
+	point.RotateY(pivot, -rotation.Pitch)
+	point.RotateX(pivot, -rotation.Roll)
+}
+
 // Add adds another point to the point
 func (point *Point3D) Add(other Point3D) {
 	point.X += other.X
@@ -63,6 +70,13 @@ func (point *Point3D) Subtract(other Point3D) {
 	point.Z -= other.Z
 }
 
+// Scale multiplies all components of the point by the given factor
+func (point *Point3D) Scale(factor Unit) {
+	point.X *= factor
+	point.Y *= factor
+	point.Z *= factor
+}
+
 // DistanceTo returns the distance between the point and another point
 func (point *Point3D) DistanceTo(other Point3D) Unit {
 	dx := point.X - other.X
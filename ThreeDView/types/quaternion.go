@@ -0,0 +1,140 @@
+package types
+
+import "math"
+
+// Quaternion represents a rotation in 3D space as a unit quaternion. Unlike Rotation3D it does not suffer from gimbal lock
+// and can be composed and interpolated without errors accumulating in the individual axes
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// IdentityQuaternion returns the quaternion that represents no rotation
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// NewQuaternionFromAxisAngle creates a quaternion that rotates around the given axis by the given angle
+func NewQuaternionFromAxisAngle(axis Point3D, angle Radians) Quaternion {
+	direction := DirectionVector{axis}
+	direction.Normalize()
+	sin := math.Sin(float64(angle) / 2)
+	return Quaternion{
+		W: math.Cos(float64(angle) / 2),
+		X: float64(direction.X) * sin,
+		Y: float64(direction.Y) * sin,
+		Z: float64(direction.Z) * sin,
+	}
+}
+
+// Multiply returns the composition of the two quaternions. The result applies other first and then the quaternion itself
+func (quaternion Quaternion) Multiply(other Quaternion) Quaternion {
+	return Quaternion{
+		W: quaternion.W*other.W - quaternion.X*other.X - quaternion.Y*other.Y - quaternion.Z*other.Z,
+		X: quaternion.W*other.X + quaternion.X*other.W + quaternion.Y*other.Z - quaternion.Z*other.Y,
+		Y: quaternion.W*other.Y - quaternion.X*other.Z + quaternion.Y*other.W + quaternion.Z*other.X,
+		Z: quaternion.W*other.Z + quaternion.X*other.Y - quaternion.Y*other.X + quaternion.Z*other.W,
+	}
+}
+
+// Conjugate returns the conjugate of the quaternion. For unit quaternions this is the inverse rotation
+func (quaternion Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: quaternion.W, X: -quaternion.X, Y: -quaternion.Y, Z: -quaternion.Z}
+}
+
+// Inverse returns the inverse of the quaternion
+func (quaternion Quaternion) Inverse() Quaternion {
+	normSquared := quaternion.Dot(quaternion)
+	if normSquared == 0 {
+		return IdentityQuaternion()
+	}
+	conjugate := quaternion.Conjugate()
+	return Quaternion{
+		W: conjugate.W / normSquared,
+		X: conjugate.X / normSquared,
+		Y: conjugate.Y / normSquared,
+		Z: conjugate.Z / normSquared,
+	}
+}
+
+// Dot returns the dot product of the quaternion with another quaternion
+func (quaternion Quaternion) Dot(other Quaternion) float64 {
+	return quaternion.W*other.W + quaternion.X*other.X + quaternion.Y*other.Y + quaternion.Z*other.Z
+}
+
+// Magnitude returns the length of the quaternion
+func (quaternion Quaternion) Magnitude() float64 {
+	return math.Sqrt(quaternion.Dot(quaternion))
+}
+
+// Normalize returns the quaternion scaled to unit length
+func (quaternion Quaternion) Normalize() Quaternion {
+	magnitude := quaternion.Magnitude()
+	if magnitude == 0 {
+		return IdentityQuaternion()
+	}
+	return Quaternion{
+		W: quaternion.W / magnitude,
+		X: quaternion.X / magnitude,
+		Y: quaternion.Y / magnitude,
+		Z: quaternion.Z / magnitude,
+	}
+}
+
+// Slerp spherically interpolates between the quaternion (t = 0) and other (t = 1) along the shortest path
+func (quaternion Quaternion) Slerp(other Quaternion, t float64) Quaternion {
+	from := quaternion.Normalize()
+	to := other.Normalize()
+	cosTheta := from.Dot(to)
+	if cosTheta < 0 {
+		to = Quaternion{W: -to.W, X: -to.X, Y: -to.Y, Z: -to.Z}
+		cosTheta = -cosTheta
+	}
+
+	if cosTheta > 0.9995 {
+		// The quaternions are almost identical, linear interpolation is accurate enough and avoids dividing by ~0
+		return Quaternion{
+			W: from.W + (to.W-from.W)*t,
+			X: from.X + (to.X-from.X)*t,
+			Y: from.Y + (to.Y-from.Y)*t,
+			Z: from.Z + (to.Z-from.Z)*t,
+		}.Normalize()
+	}
+
+	theta := math.Acos(cosTheta)
+	sinTheta := math.Sin(theta)
+	fromFactor := math.Sin((1-t)*theta) / sinTheta
+	toFactor := math.Sin(t*theta) / sinTheta
+	return Quaternion{
+		W: from.W*fromFactor + to.W*toFactor,
+		X: from.X*fromFactor + to.X*toFactor,
+		Y: from.Y*fromFactor + to.Y*toFactor,
+		Z: from.Z*fromFactor + to.Z*toFactor,
+	}
+}
+
+// RotatePoint rotates a point around the origin by the quaternion
+func (quaternion Quaternion) RotatePoint(point Point3D) Point3D {
+	p := Quaternion{X: float64(point.X), Y: float64(point.Y), Z: float64(point.Z)}
+	rotated := quaternion.Multiply(p).Multiply(quaternion.Conjugate())
+	return Point3D{X: Unit(rotated.X), Y: Unit(rotated.Y), Z: Unit(rotated.Z)}
+}
+
+// ToRotationMatrix converts the quaternion to a rotation matrix in the same layout as Rotation3D.ToRotationMatrix
+func (quaternion Quaternion) ToRotationMatrix() RotationMatrix {
+	q := quaternion.Normalize()
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return RotationMatrix{
+		{1 - 2*(yy+zz), 2 * (xy + wz), 2 * (xz - wy)},
+		{2 * (xy - wz), 1 - 2*(xx+zz), 2 * (yz + wx)},
+		{2 * (xz + wy), 2 * (yz - wx), 1 - 2*(xx+yy)},
+	}
+}
+
+// ToRotation3D converts the quaternion to a Rotation3D
+func (quaternion Quaternion) ToRotation3D() Rotation3D {
+	rotationMatrix := quaternion.ToRotationMatrix()
+	return rotationMatrix.ToRotation3D()
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func matricesEqual(a, b RotationMatrix) bool {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(a[i][j]-b[i][j]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameRotation compares two quaternions, q and -q are the same rotation
+func sameRotation(a, b Quaternion) bool {
+	return math.Abs(math.Abs(a.Normalize().Dot(b.Normalize()))-1) < epsilon
+}
+
+func TestRotationRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation Rotation3D
+	}{
+		{"identity", Rotation3D{}},
+		{"roll", Rotation3D{Roll: 30}},
+		{"pitch", Rotation3D{Pitch: -45}},
+		{"yaw", Rotation3D{Yaw: 120}},
+		{"combined", Rotation3D{Roll: 10, Pitch: 20, Yaw: 30}},
+		{"negative", Rotation3D{Roll: -170, Pitch: -80, Yaw: -100}},
+		{"yaw 180", Rotation3D{Yaw: 180}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matrix := test.rotation.ToRotationMatrix()
+			quaternion := matrix.ToQuaternion()
+			result := quaternion.ToRotation3D()
+
+			resultMatrix := result.ToRotationMatrix()
+			if !matricesEqual(matrix, resultMatrix) {
+				t.Errorf("%v became %v", test.rotation, result)
+			}
+			for _, angle := range []Degrees{result.Roll - test.rotation.Roll, result.Pitch - test.rotation.Pitch, result.Yaw - test.rotation.Yaw} {
+				if remainder := math.Mod(math.Abs(float64(angle)), 360); remainder > 1e-6 && remainder < 360-1e-6 {
+					t.Errorf("%v became %v", test.rotation, result)
+					break
+				}
+			}
+			if direct := test.rotation.ToQuaternion(); !sameRotation(direct, quaternion) {
+				t.Errorf("ToQuaternion() = %v, via matrix %v", direct, quaternion)
+			}
+		})
+	}
+}
+
+func TestRotationRoundTripGimbalLock(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation Rotation3D
+	}{
+		{"pitch 90", Rotation3D{Pitch: 90}},
+		{"pitch -90", Rotation3D{Pitch: -90}},
+		{"pitch 90 with roll and yaw", Rotation3D{Roll: 20, Pitch: 90, Yaw: 50}},
+		{"pitch -90 with roll and yaw", Rotation3D{Roll: -35, Pitch: -90, Yaw: 15}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matrix := test.rotation.ToRotationMatrix()
+			quaternion := matrix.ToQuaternion()
+			result := quaternion.ToRotation3D()
+
+			// Roll and yaw are ambiguous in gimbal lock, so only the resulting rotation has to match
+			if math.Abs(float64(result.Pitch-test.rotation.Pitch)) > 1e-6 {
+				t.Errorf("pitch of %v became %v", test.rotation, result)
+			}
+			if result.Roll != 0 {
+				t.Errorf("expected the rotation to be put into yaw, got %v", result)
+			}
+			resultMatrix := result.ToRotationMatrix()
+			if !matricesEqual(matrix, resultMatrix) {
+				t.Errorf("%v became %v", test.rotation, result)
+			}
+		})
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	from := NewQuaternionFromAxisAngle(Point3D{Z: 1}, 0)
+	to := NewQuaternionFromAxisAngle(Point3D{Z: 1}, math.Pi/2)
+	tests := []struct {
+		name     string
+		t        float64
+		expected Quaternion
+	}{
+		{"start", 0, from},
+		{"half", 0.5, NewQuaternionFromAxisAngle(Point3D{Z: 1}, math.Pi/4)},
+		{"end", 1, to},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := from.Slerp(to, test.t); !sameRotation(result, test.expected) {
+				t.Errorf("Slerp(%v) = %v, expected %v", test.t, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestSlerpShortestPath(t *testing.T) {
+	from := NewQuaternionFromAxisAngle(Point3D{Z: 1}, Degrees(10).ToRadians())
+	to := NewQuaternionFromAxisAngle(Point3D{Z: 1}, Degrees(350).ToRadians())
+	if from.Dot(to) >= 0 {
+		t.Fatalf("expected the quaternions to be in opposite hemispheres")
+	}
+
+	// The short way from 10° to 350° passes 0°, the long way would pass 180°
+	result := from.Slerp(to, 0.5)
+	if !sameRotation(result, IdentityQuaternion()) {
+		t.Errorf("Slerp(0.5) = %v, expected the identity", result)
+	}
+	if result.W < 0 {
+		t.Errorf("expected the result in the hemisphere of the start, got %v", result)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  Rotation3D
+		second Rotation3D
+	}{
+		{"identity", Rotation3D{}, Rotation3D{Roll: 10, Pitch: 20, Yaw: 30}},
+		{"same axis", Rotation3D{Yaw: 30}, Rotation3D{Yaw: 45}},
+		{"different axes", Rotation3D{Roll: 90}, Rotation3D{Pitch: 45}},
+		{"combined", Rotation3D{Roll: 15, Pitch: -30, Yaw: 60}, Rotation3D{Roll: -40, Pitch: 10, Yaw: 100}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			composed := test.first
+			composed.Compose(test.second)
+
+			firstMatrix := test.first.ToRotationMatrix()
+			expected := firstMatrix.Multiply(test.second.ToRotationMatrix())
+			if result := composed.ToRotationMatrix(); !matricesEqual(result, expected) {
+				t.Errorf("Compose gave %v, expected matrix %v", composed, expected)
+			}
+		})
+	}
+}
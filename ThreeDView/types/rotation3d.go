@@ -41,13 +41,27 @@ func (rotation *Rotation3D) ToRotationMatrix() RotationMatrix {
 	}
 }
 
-// Add adds another rotation to the rotation
+// Add adds the angles of another rotation to the rotation component-wise. This is useful for angle parameters
+// like the orbit of a camera, to apply one rotation after another use Compose
 func (rotation *Rotation3D) Add(other Rotation3D) {
 	rotation.Roll += other.Roll
 	rotation.Pitch += other.Pitch
 	rotation.Yaw += other.Yaw
 }
 
+// Compose applies another rotation after the rotation
+func (rotation *Rotation3D) Compose(other Rotation3D) {
+	*rotation = other.ToQuaternion().Multiply(rotation.ToQuaternion()).ToRotation3D()
+}
+
+// ToQuaternion converts the rotation to a Quaternion
+func (rotation *Rotation3D) ToQuaternion() Quaternion {
+	roll := NewQuaternionFromAxisAngle(Point3D{X: 1}, rotation.Roll.ToRadians())
+	pitch := NewQuaternionFromAxisAngle(Point3D{Y: 1}, rotation.Pitch.ToRadians())
+	yaw := NewQuaternionFromAxisAngle(Point3D{Z: 1}, rotation.Yaw.ToRadians())
+	return yaw.Multiply(pitch).Multiply(roll)
+}
+
 // ToDirectionVector converts the rotation to a normalized direction vector
 func (rotation *Rotation3D) ToDirectionVector() DirectionVector {
 	rotationMatrix := rotation.ToRotationMatrix()
@@ -14,6 +14,15 @@ func (rotationMatrix *RotationMatrix) ApplyInverseRotationMatrix(point Point3D)
 	}
 }
 
+// ApplyRotationMatrix applies the rotation of the rotation matrix to a point, in the same way as Point3D.Rotate
+func (rotationMatrix *RotationMatrix) ApplyRotationMatrix(point Point3D) Point3D {
+	return Point3D{
+		X: Unit(rotationMatrix[0][0]*float64(point.X) + rotationMatrix[1][0]*float64(point.Y) + rotationMatrix[2][0]*float64(point.Z)),
+		Y: Unit(rotationMatrix[0][1]*float64(point.X) + rotationMatrix[1][1]*float64(point.Y) + rotationMatrix[2][1]*float64(point.Z)),
+		Z: Unit(rotationMatrix[0][2]*float64(point.X) + rotationMatrix[1][2]*float64(point.Y) + rotationMatrix[2][2]*float64(point.Z)),
+	}
+}
+
 // Transpose transposes the rotation matrix
 func (rotationMatrix *RotationMatrix) Transpose() RotationMatrix {
 	return RotationMatrix{
@@ -39,8 +48,37 @@ func (rotationMatrix *RotationMatrix) Multiply(other RotationMatrix) RotationMat
 // ToRotation3D converts the rotation matrix to a Rotation3D
 func (rotationMatrix *RotationMatrix) ToRotation3D() Rotation3D {
 	rotation := Rotation3D{}
-	rotation.Roll = Degrees(math.Asin(rotationMatrix[1][2]))
-	rotation.Pitch = Degrees(math.Atan2(rotationMatrix[0][2], rotationMatrix[2][2]))
-	rotation.Yaw = Degrees(math.Atan2(rotationMatrix[1][0], rotationMatrix[1][1]))
+	sinPitch := -rotationMatrix[0][2]
+	if math.Abs(sinPitch) >= 1-1e-9 {
+		// Gimbal lock: roll and yaw rotate around the same axis, so the whole rotation is put into yaw
+		rotation.Pitch = Radians(math.Copysign(math.Pi/2, sinPitch)).ToDegrees()
+		rotation.Yaw = Radians(math.Atan2(-rotationMatrix[1][0], rotationMatrix[1][1])).ToDegrees()
+		return rotation
+	}
+	rotation.Roll = Radians(math.Atan2(rotationMatrix[1][2], rotationMatrix[2][2])).ToDegrees()
+	rotation.Pitch = Radians(math.Asin(sinPitch)).ToDegrees()
+	rotation.Yaw = Radians(math.Atan2(rotationMatrix[0][1], rotationMatrix[0][0])).ToDegrees()
 	return rotation
 }
+
+// ToQuaternion converts the rotation matrix to a Quaternion
+func (rotationMatrix *RotationMatrix) ToQuaternion() Quaternion {
+	m := rotationMatrix
+	trace := m[0][0] + m[1][1] + m[2][2]
+	var quaternion Quaternion
+	switch {
+	case trace > 0:
+		s := 0.5 / math.Sqrt(trace+1)
+		quaternion = Quaternion{W: 0.25 / s, X: (m[1][2] - m[2][1]) * s, Y: (m[2][0] - m[0][2]) * s, Z: (m[0][1] - m[1][0]) * s}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		quaternion = Quaternion{W: (m[1][2] - m[2][1]) / s, X: 0.25 * s, Y: (m[1][0] + m[0][1]) / s, Z: (m[2][0] + m[0][2]) / s}
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		quaternion = Quaternion{W: (m[2][0] - m[0][2]) / s, X: (m[1][0] + m[0][1]) / s, Y: 0.25 * s, Z: (m[2][1] + m[1][2]) / s}
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		quaternion = Quaternion{W: (m[0][1] - m[1][0]) / s, X: (m[2][0] + m[0][2]) / s, Y: (m[2][1] + m[1][2]) / s, Z: 0.25 * s}
+	}
+	return quaternion.Normalize()
+}
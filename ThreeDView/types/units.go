@@ -9,7 +9,7 @@ type Unit float64
 type Pixel int
 
 // Degrees represents an angle in degrees
-type Degrees int
+type Degrees float64
 
 // ToRadians converts degrees to radians
 func (degrees Degrees) ToRadians() Radians {
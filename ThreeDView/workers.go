@@ -0,0 +1,49 @@
+package ThreeDView
+
+import (
+	"runtime"
+	"sync"
+)
+
+// workerPool runs jobs on a fixed number of goroutines instead of starting a goroutine per job
+type workerPool struct {
+	jobs chan func()
+}
+
+var (
+	renderWorkers     *workerPool
+	renderWorkersOnce sync.Once
+)
+
+// getRenderWorkers returns the worker pool shared by all widgets. It is started on first use with one worker per CPU
+func getRenderWorkers() *workerPool {
+	renderWorkersOnce.Do(func() {
+		renderWorkers = newWorkerPool(runtime.NumCPU())
+	})
+	return renderWorkers
+}
+
+func newWorkerPool(workers int) *workerPool {
+	pool := &workerPool{jobs: make(chan func())}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range pool.jobs {
+				job()
+			}
+		}()
+	}
+	return pool
+}
+
+// run calls job for every index from 0 to count-1 on the workers and waits until all calls returned
+func (pool *workerPool) run(count int, job func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(count)
+	for i := 0; i < count; i++ {
+		pool.jobs <- func() {
+			defer wg.Done()
+			job(i)
+		}
+	}
+	wg.Wait()
+}
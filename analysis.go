@@ -6,6 +6,8 @@ import (
 	"fyne.io/fyne/v2/container"
 	"gonum.org/v1/plot/plotter"
 	"image/color"
+	"math"
+	"time"
 )
 
 func analysisTab() fyne.CanvasObject {
@@ -13,11 +15,13 @@ func analysisTab() fyne.CanvasObject {
 		AddTool(Graph.NewResetAxisTool()).
 		AddTool(Graph.NewZoomTool()).
 		AddTool(Graph.NewDragTool()).
+		AddTool(Graph.NewCursorTool()).
+		AddTool(Graph.NewHistoryTool()).
+		AddTool(Graph.NewSeriesTool()).
+		AddTool(Graph.NewExportTool("graph1")).
 		SetMaxBounds(0, 2, 0, 2)
 
-	l, _ := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}, {2, 2}})
-	l.Color = color.RGBA{G: 255}
-	graph1.Plot.Add(l)
+	graph1.AddSeries(Graph.NewSeries("Linear", "", plotter.XYs{{0, 0}, {1, 1}, {2, 2}}, color.RGBA{G: 255}))
 
 	grid := plotter.NewGrid()
 	graph1.Plot.Add(grid)
@@ -26,22 +30,32 @@ func analysisTab() fyne.CanvasObject {
 		AddTool(Graph.NewResetAxisTool()).
 		AddTool(Graph.NewZoomTool()).
 		AddTool(Graph.NewDragTool()).
+		AddTool(Graph.NewCursorTool()).
+		AddTool(Graph.NewHistoryTool()).
+		AddTool(Graph.NewSeriesTool()).
+		AddTool(Graph.NewExportTool("graph2")).
 		SetMaxBounds(0, 2, 0, 2)
 
-	l2, _ := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}, {2, 2}})
-	l2.Color = color.RGBA{R: 255}
-	graph2.Plot.Add(l2)
-
-	r1, _ := plotter.NewScatter(plotter.XYs{{0, 0}, {1, 2}, {2, 2}})
-	r1.GlyphStyle.Color = color.RGBA{G: 255}
-	graph2.Plot.Add(r1)
+	graph2.AddSeries(
+		Graph.NewSeries("Linear", "", plotter.XYs{{0, 0}, {1, 1}, {2, 2}}, color.RGBA{R: 255}),
+		Graph.NewSeries("Samples", "", plotter.XYs{{0, 0}, {1, 2}, {2, 2}}, color.RGBA{G: 255}).SetStyle(Graph.StyleScatter),
+	)
 
 	grid2 := plotter.NewGrid()
 	graph2.Plot.Add(grid2)
 
+	Graph.NewLinkGroup(false).Add(graph1).Add(graph2)
+
 	graph1.SetMinWidgetSize(fyne.NewSize(10, 300))
 	graph2.SetMinWidgetSize(fyne.NewSize(10, 300))
 
+	go func() {
+		sessionChannel := ps.Sub("session")
+		for loaded := range sessionChannel {
+			showSession(graph1, graph2, loaded.(LoadedSession))
+		}
+	}()
+
 	return container.NewVScroll(
 		container.NewVBox(
 			graph1,
@@ -49,3 +63,60 @@ func analysisTab() fyne.CanvasObject {
 		),
 	)
 }
+
+func showSession(altitudeGraph, accelerationGraph *Graph.Widget, loaded LoadedSession) {
+	log, offsets, err := loaded.telemetry()
+	if err != nil {
+		netLogger.Println("Skipped invalid session frames:", err)
+	}
+	if len(log) == 0 {
+		return
+	}
+	var altitude, flagged, xAcceleration, yAcceleration, zAcceleration plotter.XYs
+	for i, data := range log {
+		t := offsets[i].Seconds()
+		altitude = append(altitude, plotter.XY{X: t, Y: data.altitude})
+		if data.quality != 0 {
+			flagged = append(flagged, plotter.XY{X: t, Y: data.altitude})
+		}
+		xAcceleration = append(xAcceleration, plotter.XY{X: t, Y: data.xAcceleration})
+		yAcceleration = append(yAcceleration, plotter.XY{X: t, Y: data.yAcceleration})
+		zAcceleration = append(zAcceleration, plotter.XY{X: t, Y: data.zAcceleration})
+	}
+	title := loaded.Session.Rocket + " " + loaded.Session.Started.Format(time.DateTime)
+
+	altitudeGraph.Plot.Title.Text = "Altitude " + title
+	altitudeGraph.Plot.X.Label.Text = "Time (s)"
+	altitudeGraph.Plot.Y.Label.Text = "Altitude (m)"
+	altitudeGraph.ClearSeries().AddSeries(Graph.NewSeries("Altitude", "m", altitude, color.RGBA{G: 255, A: 255}))
+	if len(flagged) > 0 {
+		altitudeGraph.AddSeries(Graph.NewSeries("Invalid telemetry", "m", flagged, color.RGBA{R: 255, G: 128, A: 255}).SetStyle(Graph.StyleScatter))
+	}
+	xMin, xMax, yMin, yMax := plotter.XYRange(altitude)
+	xMin, xMax = expandEmptyRange(xMin, xMax)
+	yMin, yMax = expandEmptyRange(yMin, yMax)
+	altitudeGraph.SetMaxBounds(xMin, xMax, yMin, yMax)
+
+	accelerationGraph.Plot.Title.Text = "Acceleration " + title
+	accelerationGraph.Plot.X.Label.Text = "Time (s)"
+	accelerationGraph.Plot.Y.Label.Text = "Acceleration (m/s²)"
+	accelerationGraph.ClearSeries().AddSeries(
+		Graph.NewSeries("X", "m/s²", xAcceleration, color.RGBA{R: 255, A: 255}),
+		Graph.NewSeries("Y", "m/s²", yAcceleration, color.RGBA{R: 255, G: 255, A: 255}),
+		Graph.NewSeries("Z", "m/s²", zAcceleration, color.RGBA{B: 255, G: 128, A: 255}),
+	)
+	yMin, yMax = math.Inf(1), math.Inf(-1)
+	for _, series := range []plotter.XYs{xAcceleration, yAcceleration, zAcceleration} {
+		_, _, seriesMin, seriesMax := plotter.XYRange(series)
+		yMin, yMax = math.Min(yMin, seriesMin), math.Max(yMax, seriesMax)
+	}
+	yMin, yMax = expandEmptyRange(yMin, yMax)
+	accelerationGraph.SetMaxBounds(xMin, xMax, yMin, yMax)
+}
+
+func expandEmptyRange(min, max float64) (float64, float64) {
+	if min == max {
+		return min - 1, max + 1
+	}
+	return min, max
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"golang.org/x/net/websocket"
+	"hash/crc32"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// WebSocket subprotocols for the telemetry encoding. Rockets that don't negotiate a subprotocol send CSV
+const (
+	SubprotocolCSV    = "wara.csv.v1"
+	SubprotocolBinary = "wara.bin.v1"
+)
+
+// A binary frame is little-endian: version (uint8), sequence (uint32), timestamp in milliseconds (uint64), status index
+// (uint8), 15 float32 values in the order of binaryValues and a CRC-32 (IEEE) of all preceding bytes
+const (
+	binaryTimestampUnit = time.Millisecond
+	binaryFrameVersion  = 1
+	binaryFrameSize     = 1 + 4 + 8 + 1 + 15*4 + 4
+	binaryFramePrefix   = "#bin," // Binary frames are stored as text with this prefix followed by the frame in base64
+)
+
+// telemetryMessage is a received WebSocket message together with its frame type
+type telemetryMessage struct {
+	payload []byte
+	binary  bool
+}
+
+var telemetryCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		message := v.(telemetryMessage)
+		if message.binary {
+			return message.payload, websocket.BinaryFrame, nil
+		}
+		return message.payload, websocket.TextFrame, nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		message := v.(*telemetryMessage)
+		message.payload = data
+		message.binary = payloadType == websocket.BinaryFrame
+		return nil
+	},
+}
+
+func binaryValues(data *Data) []*float64 {
+	return []*float64{
+		&data.altitude, &data.maxAltitude, &data.voltage,
+		&data.xRotation, &data.yRotation, &data.zRotation,
+		&data.xRotationSpeed, &data.yRotationSpeed, &data.zRotationSpeed,
+		&data.xAcceleration, &data.yAcceleration, &data.zAcceleration,
+		&data.xVelocity, &data.yVelocity, &data.zVelocity,
+	}
+}
+
+// negotiateSubprotocol picks the encoding for a connection from the subprotocols offered by the client
+func negotiateSubprotocol(offered []string) []string {
+	for _, protocol := range []string{SubprotocolBinary, SubprotocolCSV} {
+		if slices.Contains(offered, protocol) {
+			return []string{protocol}
+		}
+	}
+	return nil
+}
+
+func encodeBinaryFrame(data Data, sequence uint32) []byte {
+	frame := make([]byte, 0, binaryFrameSize)
+	frame = append(frame, binaryFrameVersion)
+	frame = binary.LittleEndian.AppendUint32(frame, sequence)
+	timestamp, _ := strconv.ParseUint(data.timestamp, 10, 64)
+	frame = binary.LittleEndian.AppendUint64(frame, timestamp)
+	frame = append(frame, byte(data.status.toIndex()))
+	for _, value := range binaryValues(&data) {
+		frame = binary.LittleEndian.AppendUint32(frame, math.Float32bits(float32(*value)))
+	}
+	return binary.LittleEndian.AppendUint32(frame, crc32.ChecksumIEEE(frame))
+}
+
+func decodeBinaryFrame(frame []byte) (Data, uint32, error) {
+	if len(frame) != binaryFrameSize {
+		return Data{}, 0, fmt.Errorf("binary frame has %d bytes, expected %d", len(frame), binaryFrameSize)
+	}
+	checksum := binary.LittleEndian.Uint32(frame[binaryFrameSize-4:])
+	if crc32.ChecksumIEEE(frame[:binaryFrameSize-4]) != checksum {
+		return Data{}, 0, errors.New("binary frame checksum mismatch")
+	}
+	if frame[0] != binaryFrameVersion {
+		return Data{}, 0, fmt.Errorf("unsupported binary frame version %d", frame[0])
+	}
+	if int(frame[13]) > Status(StatusError).toIndex() {
+		return Data{}, 0, fmt.Errorf("invalid status index %d", frame[13])
+	}
+
+	data := defaultTelemetry
+	sequence := binary.LittleEndian.Uint32(frame[1:])
+	data.timestamp = strconv.FormatUint(binary.LittleEndian.Uint64(frame[5:]), 10)
+	data.status = toStatus(strconv.Itoa(int(frame[13])))
+	for i, value := range binaryValues(&data) {
+		*value = float64(math.Float32frombits(binary.LittleEndian.Uint32(frame[14+4*i:])))
+	}
+	return data, sequence, nil
+}
+
+// binaryFrameText stores a binary frame as text, e.g. in session recordings
+func binaryFrameText(frame []byte) string {
+	return binaryFramePrefix + base64.StdEncoding.EncodeToString(frame)
+}
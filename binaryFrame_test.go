@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// withChecksum replaces the CRC of a modified frame, so the decoder gets past the checksum
+func withChecksum(frame []byte) []byte {
+	frame = slices.Clone(frame)
+	binary.LittleEndian.PutUint32(frame[binaryFrameSize-4:], crc32.ChecksumIEEE(frame[:binaryFrameSize-4]))
+	return frame
+}
+
+func TestBinaryFrameRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     Data
+		sequence uint32
+	}{
+		{"idle", Data{timestamp: "0", status: StatusIdle}, 0},
+		{"powered ascent", Data{
+			timestamp: "1234", status: StatusPoweredAscent, altitude: 12.5, maxAltitude: 13, voltage: 7.25,
+			xRotation: 1, yRotation: -2, zRotation: 90, xRotationSpeed: 10, yRotationSpeed: -20, zRotationSpeed: 30,
+			xAcceleration: 0.5, yAcceleration: -0.25, zAcceleration: 40, xVelocity: 1.5, yVelocity: -1, zVelocity: 25,
+		}, 42},
+		{"error status", Data{timestamp: "99", status: StatusError}, 7},
+		{"last sequence", Data{timestamp: "18446744073709551615", status: StatusLanded}, 1<<32 - 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frame := encodeBinaryFrame(test.data, test.sequence)
+			if len(frame) != binaryFrameSize {
+				t.Fatalf("encoded %d bytes, expected %d", len(frame), binaryFrameSize)
+			}
+			data, sequence, err := decodeBinaryFrame(frame)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sequence != test.sequence {
+				t.Errorf("sequence %d, expected %d", sequence, test.sequence)
+			}
+			if data.timestamp != test.data.timestamp || data.status != test.data.status {
+				t.Errorf("timestamp %s and status %s, expected %s and %s", data.timestamp, data.status, test.data.timestamp, test.data.status)
+			}
+			expected := binaryValues(&test.data)
+			for i, value := range binaryValues(&data) {
+				if *value != *expected[i] {
+					t.Errorf("value %d is %v, expected %v", i, *value, *expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeBinaryFrameErrors(t *testing.T) {
+	valid := encodeBinaryFrame(Data{timestamp: "1000", status: StatusDescent, altitude: 50}, 3)
+	corrupted := slices.Clone(valid)
+	corrupted[20] ^= 0xff
+	wrongVersion := slices.Clone(valid)
+	wrongVersion[0] = binaryFrameVersion + 1
+	statusOutOfRange := slices.Clone(valid)
+	statusOutOfRange[13] = byte(Status(StatusError).toIndex() + 1)
+
+	tests := []struct {
+		name  string
+		frame []byte
+		error string
+	}{
+		{"empty", nil, "has 0 bytes"},
+		{"too short", valid[:binaryFrameSize-1], "expected"},
+		{"too long", append(slices.Clone(valid), 0), "expected"},
+		{"checksum mismatch", corrupted, "checksum mismatch"},
+		{"wrong version", withChecksum(wrongVersion), "unsupported binary frame version"},
+		{"status out of range", withChecksum(statusOutOfRange), "invalid status index"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := decodeBinaryFrame(test.frame)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), test.error) {
+				t.Errorf("error %q doesn't contain %q", err, test.error)
+			}
+		})
+	}
+}
+
+func TestNegotiateSubprotocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		offered  []string
+		expected []string
+	}{
+		{"binary preferred", []string{SubprotocolCSV, SubprotocolBinary}, []string{SubprotocolBinary}},
+		{"binary only", []string{SubprotocolBinary}, []string{SubprotocolBinary}},
+		{"csv only", []string{SubprotocolCSV}, []string{SubprotocolCSV}},
+		{"unknown", []string{"wara.xml.v1"}, nil},
+		{"none", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := negotiateSubprotocol(test.offered); !slices.Equal(result, test.expected) {
+				t.Errorf("negotiateSubprotocol(%v) = %v, expected %v", test.offered, result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	CheckVoltageAbove        = "voltage-above"
+	CheckStatus              = "status"
+	CheckLoggingStatus       = "logging-status"
+	CheckBaseStationPressure = "base-station-pressure"
+)
+
+const flightDirectoryName = "flights"
+
+const defaultChecklist = `
+name: Pre-flight
+sections:
+  - name: Rocket
+    items:
+      - text: Battery voltage above 7 V
+        required: true
+        check:
+          type: voltage-above
+          threshold: 7
+      - text: Flight computer idle
+        required: true
+        check:
+          type: status
+          value: idle
+      - text: Parachute packed and door closed
+        required: true
+      - text: Stage coupling seated
+        notes: Check both locking pins
+  - name: Logging
+    items:
+      - text: Logging started
+        required: true
+        check:
+          type: logging-status
+          value: logging
+  - name: Base station
+    items:
+      - text: Pressure sensor calibrated
+        required: true
+        notes: Recalibrate with the launch tube open, the reading should be ambient pressure
+        check:
+          type: base-station-pressure
+          min: 0.9
+          max: 1.1
+      - text: Launch area clear
+        required: true
+`
+
+type Checklist struct {
+	Name     string             `yaml:"name" json:"name"`
+	Sections []ChecklistSection `yaml:"sections" json:"sections"`
+}
+
+type ChecklistSection struct {
+	Name  string          `yaml:"name" json:"name"`
+	Items []ChecklistItem `yaml:"items" json:"items"`
+}
+
+type ChecklistItem struct {
+	Text     string          `yaml:"text" json:"text"`
+	Required bool            `yaml:"required" json:"required"`
+	Notes    string          `yaml:"notes" json:"notes"`
+	Check    *AutomatedCheck `yaml:"check" json:"check"`
+}
+
+type AutomatedCheck struct {
+	Type      string  `yaml:"type" json:"type"`
+	Value     string  `yaml:"value" json:"value"`
+	Threshold float64 `yaml:"threshold" json:"threshold"`
+	Min       float64 `yaml:"min" json:"min"`
+	Max       float64 `yaml:"max" json:"max"`
+}
+
+type CheckResult struct {
+	Passed bool
+	Detail string
+}
+
+type ChecklistRun struct {
+	Checklist string             `json:"checklist"`
+	Started   time.Time          `json:"started"`
+	Completed time.Time          `json:"completed"`
+	Items     []ChecklistRunItem `json:"items"`
+}
+
+type ChecklistRunItem struct {
+	Section     string    `json:"section"`
+	Text        string    `json:"text"`
+	Required    bool      `json:"required"`
+	Done        bool      `json:"done"`
+	SignedOffBy string    `json:"signedOffBy,omitempty"`
+	SignedOffAt time.Time `json:"signedOffAt"`
+	CheckResult string    `json:"checkResult,omitempty"`
+}
+
+func parseChecklist(name string, reader io.Reader) (Checklist, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return Checklist{}, err
+	}
+	var checklist Checklist
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		err = json.Unmarshal(content, &checklist)
+	} else {
+		err = yaml.Unmarshal(content, &checklist)
+	}
+	if err != nil {
+		return Checklist{}, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	if err := checklist.validate(); err != nil {
+		return Checklist{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return checklist, nil
+}
+
+func (checklist Checklist) validate() error {
+	if checklist.Name == "" {
+		return errors.New("checklist has no name")
+	}
+	if len(checklist.Sections) == 0 {
+		return errors.New("checklist has no sections")
+	}
+	for _, section := range checklist.Sections {
+		for i, item := range section.Items {
+			if item.Text == "" {
+				return fmt.Errorf("item %d in section %q has no text", i+1, section.Name)
+			}
+			if item.Check == nil {
+				continue
+			}
+			switch item.Check.Type {
+			case CheckVoltageAbove:
+			case CheckStatus, CheckLoggingStatus:
+				if item.Check.Value == "" {
+					return fmt.Errorf("check of %q needs a value", item.Text)
+				}
+			case CheckBaseStationPressure:
+				if item.Check.Max <= item.Check.Min {
+					return fmt.Errorf("check of %q needs min below max", item.Text)
+				}
+			default:
+				return fmt.Errorf("unknown check type %q in %q", item.Check.Type, item.Text)
+			}
+		}
+	}
+	return nil
+}
+
+func (check AutomatedCheck) usesTelemetry() bool {
+	return check.Type == CheckVoltageAbove || check.Type == CheckStatus
+}
+
+func (check AutomatedCheck) evaluateTelemetry(data Data) CheckResult {
+	switch check.Type {
+	case CheckVoltageAbove:
+		return CheckResult{
+			Passed: data.voltage > check.Threshold,
+			Detail: fmt.Sprintf("%.2f V (needs > %.2f V)", data.voltage, check.Threshold),
+		}
+	case CheckStatus:
+		return CheckResult{
+			Passed: string(data.status) == check.Value,
+			Detail: fmt.Sprintf("status %s (needs %s)", data.status, check.Value),
+		}
+	}
+	return CheckResult{Detail: "not a telemetry check"}
+}
+
+func (check AutomatedCheck) evaluateRemote(profile RocketProfile) CheckResult {
+	switch check.Type {
+	case CheckLoggingStatus:
+		status := getLoggingStatus(profile)
+		return CheckResult{
+			Passed: string(status) == check.Value,
+			Detail: fmt.Sprintf("logging status %s (needs %s)", status, check.Value),
+		}
+	case CheckBaseStationPressure:
+		pressure, err := getBaseStationPressure(profile)
+		if err != nil {
+			return CheckResult{Detail: fmt.Sprintf("no pressure reading: %v", err)}
+		}
+		return CheckResult{
+			Passed: pressure >= check.Min && pressure <= check.Max,
+			Detail: fmt.Sprintf("pressure %.3f (needs %.3f to %.3f)", pressure, check.Min, check.Max),
+		}
+	}
+	return CheckResult{Detail: "not a remote check"}
+}
+
+func flightDirectory(App fyne.App) (string, error) {
+	directory := getSettings().LogDirectory
+	if directory == "" {
+		directory = filepath.Join(App.Storage().RootURI().Path(), flightDirectoryName)
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+	return directory, nil
+}
+
+func saveChecklistRun(App fyne.App, run ChecklistRun) (string, error) {
+	directory, err := flightDirectory(App)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ReplaceAll(strings.ToLower(run.Checklist), " ", "-")
+	path := filepath.Join(directory, fmt.Sprintf("checklist-%s-%s.json", name, run.Started.Format("20060102-150405")))
+	content, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, content, 0644)
+}
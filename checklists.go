@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+type checklistSession struct {
+	checklist Checklist
+	run       ChecklistRun
+	items     []ChecklistItem
+	checks    []*widget.Check
+	results   []*widget.Label
+	passed    []bool
+	operator  func() string
+	window    fyne.Window
+	mu        sync.Mutex
+}
+
+func newChecklistSession(checklist Checklist, operator func() string, window fyne.Window) *checklistSession {
+	session := &checklistSession{
+		checklist: checklist,
+		run:       ChecklistRun{Checklist: checklist.Name, Started: time.Now()},
+		operator:  operator,
+		window:    window,
+	}
+	for _, section := range checklist.Sections {
+		for _, item := range section.Items {
+			session.items = append(session.items, item)
+			session.run.Items = append(session.run.Items, ChecklistRunItem{Section: section.Name, Text: item.Text, Required: item.Required})
+		}
+	}
+	session.passed = make([]bool, len(session.items))
+	return session
+}
+
+func (session *checklistSession) view() fyne.CanvasObject {
+	rows := container.NewVBox()
+	index := 0
+	for _, section := range session.checklist.Sections {
+		rows.Add(widget.NewLabelWithStyle(section.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for _, item := range section.Items {
+			i := index
+			text := item.Text
+			if item.Required {
+				text += " *"
+			}
+			check := widget.NewCheck(text, func(done bool) { session.signOff(i, done) })
+			result := widget.NewLabel("")
+			if item.Check != nil {
+				result.SetText("Waiting for check")
+				check.Disable()
+			}
+			session.checks = append(session.checks, check)
+			session.results = append(session.results, result)
+
+			row := container.NewVBox(container.NewHBox(check, result))
+			if item.Notes != "" {
+				notes := widget.NewLabelWithStyle(item.Notes, fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+				notes.Wrapping = fyne.TextWrapWord
+				row.Add(notes)
+			}
+			rows.Add(row)
+			index++
+		}
+	}
+	return container.NewVScroll(rows)
+}
+
+func (session *checklistSession) signOff(index int, done bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	item := &session.run.Items[index]
+	if !done {
+		*item = ChecklistRunItem{Section: item.Section, Text: item.Text, Required: item.Required}
+		return
+	}
+	operator := strings.TrimSpace(session.operator())
+	if operator == "" {
+		dialog.ShowError(errors.New("enter your name before signing off items"), session.window)
+		go session.checks[index].SetChecked(false)
+		return
+	}
+	item.Done = true
+	item.SignedOffBy = operator
+	item.SignedOffAt = time.Now()
+	item.CheckResult = session.results[index].Text
+}
+
+func (session *checklistSession) setResult(index int, result CheckResult) {
+	session.mu.Lock()
+	session.passed[index] = result.Passed
+	session.mu.Unlock()
+
+	if result.Passed {
+		session.results[index].SetText("✓ " + result.Detail)
+		session.checks[index].Enable()
+	} else {
+		session.results[index].SetText("✗ " + result.Detail)
+		if !session.checks[index].Checked {
+			session.checks[index].Disable()
+		}
+	}
+}
+
+func (session *checklistSession) updateTelemetry(data Data) {
+	for i, item := range session.items {
+		if item.Check != nil && item.Check.usesTelemetry() {
+			session.setResult(i, item.Check.evaluateTelemetry(data))
+		}
+	}
+}
+
+func (session *checklistSession) runRemoteChecks(profile RocketProfile) {
+	for i, item := range session.items {
+		if item.Check != nil && !item.Check.usesTelemetry() {
+			session.results[i].SetText("Checking...")
+			session.setResult(i, item.Check.evaluateRemote(profile))
+		}
+	}
+}
+
+func (session *checklistSession) missingRequired() int {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	missing := 0
+	for _, item := range session.run.Items {
+		if item.Required && !item.Done {
+			missing++
+		}
+	}
+	return missing
+}
+
+func (session *checklistSession) save(App fyne.App) {
+	save := func() {
+		session.mu.Lock()
+		session.run.Completed = time.Now()
+		run := session.run
+		session.mu.Unlock()
+		path, err := saveChecklistRun(App, run)
+		if err != nil {
+			dialog.ShowError(err, session.window)
+			return
+		}
+		dialog.ShowInformation("Checklist saved", "The checklist run was saved to "+path, session.window)
+	}
+
+	if missing := session.missingRequired(); missing > 0 {
+		dialog.ShowConfirm("Incomplete checklist", "Required items are not signed off. Save anyway?", func(ok bool) {
+			if ok {
+				save()
+			}
+		}, session.window)
+		return
+	}
+	save()
+}
+
+func checklistsTab(App fyne.App, window fyne.Window) fyne.CanvasObject {
+	checklists := map[string]Checklist{}
+	var names []string
+	if builtin, err := parseChecklist("default.yaml", strings.NewReader(defaultChecklist)); err != nil {
+		log.Println("Default checklist:", err)
+	} else {
+		checklists[builtin.Name] = builtin
+		names = append(names, builtin.Name)
+	}
+
+	operatorEntry := widget.NewEntry()
+	operatorEntry.SetPlaceHolder("Signed off by")
+
+	var session *checklistSession
+	var sessionMu sync.Mutex
+	var latest Data
+	content := container.NewStack()
+
+	start := func(name string) {
+		checklist, ok := checklists[name]
+		if !ok {
+			return
+		}
+		newSession := newChecklistSession(checklist, func() string { return operatorEntry.Text }, window)
+		content.Objects = []fyne.CanvasObject{newSession.view()}
+		content.Refresh()
+
+		sessionMu.Lock()
+		session = newSession
+		data := latest
+		sessionMu.Unlock()
+		newSession.updateTelemetry(data)
+	}
+	current := func() *checklistSession {
+		sessionMu.Lock()
+		defer sessionMu.Unlock()
+		return session
+	}
+
+	checklistSelect := widget.NewSelect(names, start)
+
+	loadButton := widget.NewButton("Load checklist", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			checklist, err := parseChecklist(reader.URI().Name(), reader)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if _, exists := checklists[checklist.Name]; !exists {
+				names = append(names, checklist.Name)
+				checklistSelect.Options = names
+			}
+			checklists[checklist.Name] = checklist
+			if checklistSelect.Selected == checklist.Name {
+				start(checklist.Name)
+			} else {
+				checklistSelect.SetSelected(checklist.Name)
+			}
+		}, window)
+	})
+	newRunButton := widget.NewButton("New run", func() { start(checklistSelect.Selected) })
+	runChecksButton := widget.NewButton("Run checks", func() {
+		if session := current(); session != nil {
+			go session.runRemoteChecks(primaryProfile())
+		}
+	})
+	saveButton := widget.NewButton("Save run", func() {
+		if session := current(); session != nil {
+			session.save(App)
+		}
+	})
+
+	selectProfileChecklist := func(profile RocketProfile) {
+		if _, ok := checklists[profile.Checklist]; ok {
+			checklistSelect.SetSelected(profile.Checklist)
+		}
+	}
+	if len(names) > 0 {
+		checklistSelect.SetSelected(names[0])
+	}
+	selectProfileChecklist(getProfile())
+	go func() {
+		profileChannel := ps.Sub("profile")
+		for profile := range profileChannel {
+			selectProfileChecklist(profile.(RocketProfile))
+		}
+	}()
+
+	go func() {
+		newestDataChannel := ps.Sub("newData")
+		for newestData := range newestDataChannel {
+			newestData := newestData.(Data)
+			if !isActiveSource(newestData) {
+				continue
+			}
+			sessionMu.Lock()
+			latest = newestData
+			session := session
+			sessionMu.Unlock()
+			if session != nil {
+				session.updateTelemetry(newestData)
+			}
+		}
+	}()
+
+	toolbar := container.NewVBox(
+		container.NewGridWithColumns(3, checklistSelect, loadButton, operatorEntry),
+		container.NewGridWithColumns(3, newRunButton, runChecksButton, saveButton),
+	)
+	return container.NewBorder(toolbar, nil, nil, nil, content)
+}
@@ -1,102 +1,178 @@
 package main
 
 import (
+	"FlightControl/Graph"
 	"FlightControl/ThreeDView"
 	"FlightControl/ThreeDView/camera"
 	"FlightControl/ThreeDView/types"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot/plotter"
+	"image/color"
 	"math"
+	"strings"
+	"sync"
+	"time"
 )
 
-var voltageLabel *widget.Label
-var statusLabel *widget.Label
-var heightLabel *widget.Label
-var maxHeightLabel *widget.Label
+const (
+	liveChartWindow   = 30 // Seconds shown in the live charts
+	liveChartCapacity = 5000
+	liveChartFPS      = 10
+)
+
+const rocketSpacing = 400 // Distance between the launch pads of the rockets in the 3D scene
+
+type rocketPanel struct {
+	card      *widget.Card
+	voltage   *widget.Label
+	status    *widget.Label
+	height    *widget.Label
+	maxHeight *widget.Label
+	alarms    *widget.Label
+	warnings  *widget.Label
+}
+
+// newRocketPanel creates the panel of the rocket called name. commands is shown below the telemetry if it isn't nil
+func newRocketPanel(name string, commands fyne.CanvasObject) *rocketPanel {
+	panel := &rocketPanel{
+		voltage:   widget.NewLabel("Voltage: N/A"),
+		status:    widget.NewLabel("Status: Not connected"),
+		height:    widget.NewLabel("Height: N/A"),
+		maxHeight: widget.NewLabel("Max height: N/A"),
+		alarms:    widget.NewLabel(""),
+		warnings:  widget.NewLabel(""),
+	}
+	panel.alarms.Importance = widget.DangerImportance
+	panel.alarms.Hide()
+	panel.warnings.Importance = widget.WarningImportance
+	panel.warnings.Hide()
+	content := container.NewVBox(panel.voltage, panel.status, panel.height, panel.maxHeight, panel.alarms, panel.warnings)
+	if commands != nil {
+		content.Add(commands)
+	}
+	panel.card = widget.NewCard(name, "", content)
+	return panel
+}
+
+// rocketCommands returns the buttons that send commands to the rocket called name. onLog is called with the log once
+// it has been downloaded
+func rocketCommands(name string, onLog func(Log)) fyne.CanvasObject {
+	command := func(label string, send func(RocketProfile)) *widget.Button {
+		return widget.NewButton(label, func() {
+			if profile, ok := rocketProfile(name); ok {
+				go send(profile)
+			}
+		})
+	}
+	buttons := []fyne.CanvasObject{
+		command("Reset", reset),
+		command("Deploy parachute", deployParachute),
+		command("Deploy stage", deployStage),
+		command("Get log", func(profile RocketProfile) { onLog(getLog(profile)) }),
+	}
 
-func updateVoltage(voltage float64) {
-	voltageLabel.SetText("Voltage: " + fmt.Sprintf("%f", voltage) + " V")
+	if fyne.CurrentDevice().IsMobile() && fyne.CurrentDevice().Orientation() == 0 {
+		return container.NewVBox(buttons...)
+	}
+	return container.NewGridWithColumns(2, buttons...)
 }
 
-func updateStatus(status string) {
-	statusLabel.SetText("Status: " + status)
+func (panel *rocketPanel) update(data Data) {
+	settings := getSettings()
+	panel.voltage.SetText("Voltage: " + fmt.Sprintf("%f", data.voltage) + " V")
+	panel.status.SetText("Status: " + string(data.status))
+	panel.height.SetText("Height: " + settings.formatDistance(data.altitude))
+	panel.maxHeight.SetText("Max height: " + settings.formatDistance(data.maxAltitude))
+
+	if data.quality == 0 {
+		panel.warnings.Hide()
+	} else {
+		panel.warnings.SetText("Invalid telemetry: " + strings.Join(data.warnings, "\n"))
+		panel.warnings.Show()
+	}
+
+	alarms := settings.alarms(data)
+	if len(alarms) == 0 {
+		panel.alarms.Hide()
+		return
+	}
+	panel.alarms.SetText(strings.Join(alarms, "\n"))
+	panel.alarms.Show()
 }
 
-func updateHeight(height float64) {
-	heightLabel.SetText("Height: " + fmt.Sprintf("%f", height) + " m")
+// dataSource returns the name of the rocket a sample belongs to. Samples without a source belong to the active rocket
+func dataSource(data Data) string {
+	if data.source == "" {
+		return getProfile().Name
+	}
+	return data.source
 }
 
-func updateMaxHeight(maxHeight float64) {
-	maxHeightLabel.SetText("Max height: " + fmt.Sprintf("%f", maxHeight) + " m")
+func isActiveSource(data Data) bool {
+	return dataSource(data) == getProfile().Name
 }
 
 func controlTab(App fyne.App, MainWindow fyne.Window) fyne.CanvasObject {
-	ipLabel := widget.NewLabel("WaRa IP: " + App.Preferences().StringWithFallback("WaRaIP", "Not set"))
-	voltageLabel = widget.NewLabel("Voltage: N/A")
-	statusLabel = widget.NewLabel("Status: Not connected")
-	heightLabel = widget.NewLabel("Height: N/A")
-	maxHeightLabel = widget.NewLabel("Max height: N/A")
-
-	App.Preferences().AddChangeListener(func() {
-		ipLabel.SetText("WaRa IP: " + App.Preferences().StringWithFallback("WaRaIP", "Not set"))
-	})
+	ipLabel := widget.NewLabel("WaRa IP: " + hostLabel(getSettings().RocketHost))
 
-	threeDVisualisation, rocket := threeDVisualisation()
+	go func() {
+		settingsChannel := ps.Sub("settings")
+		for settings := range settingsChannel {
+			ipLabel.SetText("WaRa IP: " + hostLabel(settings.(Settings).RocketHost))
+		}
+	}()
 
-	resetButton := widget.NewButton("Reset", func() { go reset(App) })
-	deployParachuteButton := widget.NewButton("Deploy parachute", func() { go deployParachute(App) })
-	deployStageButton := widget.NewButton("Deploy stage", func() { go deployStage(App) })
-	getLogButton := widget.NewButton("Get log", func() { go getLog(App) })
+	scene := newRocketScene()
 
-	ipEditButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
-		ipEntry := widget.NewEntry()
-		ipEntry.SetPlaceHolder("Enter IP")
-		dialog.ShowForm("Set WaRa IP", "OK", "Cancel", []*widget.FormItem{
-			widget.NewFormItem("IP", ipEntry),
-		}, func(ok bool) {
-			if !ok {
-				return
-			}
-			App.Preferences().SetString("WaRaIP", ipEntry.Text)
-			updateWebsocket(App)
-		}, MainWindow)
-	})
+	ipEditButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() { showRocketHostDialog(App, MainWindow) })
+
+	panels := map[string]*rocketPanel{}
+	panelContainer := container.NewVBox()
+	panel := func(source string) *rocketPanel {
+		if panel, ok := panels[source]; ok {
+			return panel
+		}
+		var commands fyne.CanvasObject
+		if source != replaySource {
+			commands = rocketCommands(source, func(log Log) {
+				_, trail := scene.rocket(source)
+				trail.LoadLog(log)
+				ps.Pub(DownloadedLog{Source: source, Log: log}, "log")
+			})
+		}
+		panel := newRocketPanel(source, commands)
+		panels[source] = panel
+		panelContainer.Add(panel.card)
+		return panel
+	}
+	panel(getProfile().Name)
 
 	infoLabelContainer := container.NewVBox(
-		container.NewHBox(ipLabel, ipEditButton), voltageLabel, statusLabel, heightLabel, maxHeightLabel,
+		profileSwitcher(App, MainWindow),
+		container.NewHBox(ipLabel, ipEditButton),
+		widget.NewAccordion(
+			widget.NewAccordionItem("Connected rockets", connectedRocketsSelector(App)),
+			widget.NewAccordionItem("Replay", replayControls(MainWindow)),
+			widget.NewAccordionItem("Link diagnostics", linkDiagnostics()),
+		),
+		panelContainer,
 	)
 
 	infoContainer := container.NewGridWithColumns(3,
 		infoLabelContainer,
 		container.NewCenter(),
-		threeDVisualisation,
+		scene.widget,
 	)
 
-	buttons := []fyne.CanvasObject{
-		resetButton,
-		deployParachuteButton,
-		deployStageButton,
-		getLogButton,
-	}
-
-	var buttonContainer *fyne.Container
-	if fyne.CurrentDevice().IsMobile() && fyne.CurrentDevice().Orientation() == 0 {
-		buttonContainer = container.NewVBox(
-			buttons...,
-		)
-	} else {
-		buttonContainer = container.NewGridWithColumns(3,
-			buttons...,
-		)
-	}
+	altitudeChart, accelerationChart, addChartSample, clearCharts := liveCharts()
 
 	content := container.NewVBox(
 		infoContainer,
-		buttonContainer,
+		container.NewGridWithColumns(2, altitudeChart, accelerationChart),
 	)
 
 	go func() {
@@ -104,9 +180,9 @@ func controlTab(App fyne.App, MainWindow fyne.Window) fyne.CanvasObject {
 		for selectedTab := range selectedTabChannel {
 			selectedTab := selectedTab.(*container.TabItem)
 			if selectedTab.Text == "Control" {
-				threeDVisualisation.Show()
+				scene.widget.Show()
 			} else {
-				threeDVisualisation.Hide()
+				scene.widget.Hide()
 			}
 		}
 	}()
@@ -114,43 +190,265 @@ func controlTab(App fyne.App, MainWindow fyne.Window) fyne.CanvasObject {
 		newestDataChannel := ps.Sub("newData")
 		for newestData := range newestDataChannel {
 			newestData := newestData.(Data)
-			updateVoltage(newestData.voltage)
-			updateStatus(string(newestData.status))
-			updateHeight(newestData.altitude)
-			updateMaxHeight(newestData.maxAltitude)
+			source := dataSource(newestData)
+			panel(source).update(newestData)
 
-			rocket.DataChannel <- newestData
+			rocket, trail := scene.rocket(source)
+			rocket.ApplyData(newestData)
+			trail.AddSample(newestData)
+		}
+	}()
+	go func() {
+		// The charts show the replay while a log is loaded for replay and the active rocket otherwise. Seeks and samples
+		// come through one channel, so the samples after a seek are never cleared by it
+		start := time.Now()
+		chartingReplay := false
+		channel := ps.Sub("newData", "replaySeek")
+		for message := range channel {
+			switch message := message.(type) {
+			case ReplaySeek:
+				clearCharts()
+				chartingReplay = len(replay.Loaded()) > 0
+				start = time.Now()
+				for _, data := range message.Played {
+					addChartSample(data.replayTime.Seconds(), data)
+				}
+			case Data:
+				if chartingReplay && message.source == replaySource {
+					addChartSample(message.replayTime.Seconds(), message)
+				} else if !chartingReplay && isActiveSource(message) {
+					addChartSample(time.Since(start).Seconds(), message)
+				}
+			}
+		}
+	}()
+
+	return content
+}
+
+// linkDiagnostics shows the link statistics of every connected rocket
+func linkDiagnostics() fyne.CanvasObject {
+	labels := map[string]*widget.Label{}
+	content := container.NewVBox(widget.NewLabel("No telemetry received yet"))
+
+	go func() {
+		linkStatsChannel := ps.Sub("linkStats")
+		for stats := range linkStatsChannel {
+			stats := stats.(LinkStats)
+			label, ok := labels[stats.Source]
+			if !ok {
+				if len(labels) == 0 {
+					content.RemoveAll()
+				}
+				label = widget.NewLabel("")
+				labels[stats.Source] = label
+				content.Add(widget.NewCard(stats.Source, "", label))
+			}
+			label.SetText(stats.describe(time.Now()))
 		}
 	}()
 
 	return content
 }
 
-func threeDVisualisation() (fyne.CanvasObject, *Rocket) {
+// connectedRocketsSelector lets the user pick the rockets that are connected in addition to the active one
+func connectedRocketsSelector(App fyne.App) fyne.CanvasObject {
+	options := func() []string {
+		var names []string
+		for _, profile := range getProfiles() {
+			if profile.Name != getProfile().Name {
+				names = append(names, profile.Name)
+			}
+		}
+		return names
+	}
+
+	checkGroup := widget.NewCheckGroup(options(), nil)
+	checkGroup.Selected = extraRockets(App)
+	checkGroup.OnChanged = func(selected []string) {
+		go setExtraRockets(App, selected)
+	}
+
+	go func() {
+		profileChannel := ps.Sub("profile")
+		for range profileChannel {
+			checkGroup.Options = options()
+			checkGroup.Refresh()
+		}
+	}()
+
+	return checkGroup
+}
+
+// liveCharts returns the altitude and acceleration charts, a function that adds a sample at time t in seconds and a
+// function that clears them
+func liveCharts() (*Graph.Widget, *Graph.Widget, func(t float64, data Data), func()) {
+	newChart := func(title, yLabel string) *Graph.Widget {
+		chart := Graph.NewGraphWidget().
+			AddTool(Graph.NewResetAxisTool()).
+			AddTool(Graph.NewDragTool()).
+			AddTool(Graph.NewSeriesTool()).
+			AddTool(Graph.NewExportTool(strings.ToLower(title))).
+			SetStreamingWindow(liveChartWindow).
+			SetMaxRefreshRate(liveChartFPS)
+		chart.Plot.Title.Text = title
+		chart.Plot.Title.TextStyle.Color = color.White
+		chart.Plot.X.Label.Text = "Time (s)"
+		chart.Plot.Y.Label.Text = yLabel
+		chart.Plot.Add(plotter.NewGrid())
+		chart.SetMinWidgetSize(fyne.NewSize(10, 200))
+		return chart
+	}
+
+	altitude := Graph.NewStreamingSeries(liveChartCapacity, color.RGBA{G: 255, A: 255})
+	voltage := Graph.NewStreamingSeries(liveChartCapacity, color.RGBA{R: 255, G: 128, A: 255})
+	xAcceleration := Graph.NewStreamingSeries(liveChartCapacity, color.RGBA{R: 255, A: 255})
+	yAcceleration := Graph.NewStreamingSeries(liveChartCapacity, color.RGBA{R: 255, G: 255, A: 255})
+	zAcceleration := Graph.NewStreamingSeries(liveChartCapacity, color.RGBA{B: 255, G: 128, A: 255})
+
+	altitudeChart := newChart("Altitude", "Altitude (m)").
+		SetRightAxis("Voltage (V)", 0, 10).
+		AddStreamingSeries("Altitude", "m", altitude).
+		AddSeries(Graph.NewSeries("Voltage", "V", voltage, voltage.LineStyle.Color).SetAxis(Graph.AxisRight))
+	accelerationChart := newChart("Acceleration", "Acceleration (m/s²)").
+		AddStreamingSeries("X", "m/s²", xAcceleration).
+		AddStreamingSeries("Y", "m/s²", yAcceleration).
+		AddStreamingSeries("Z", "m/s²", zAcceleration)
+
+	series := []*Graph.StreamingSeries{altitude, voltage, xAcceleration, yAcceleration, zAcceleration}
+	addSample := func(t float64, data Data) {
+		altitude.Append(t, data.altitude)
+		voltage.Append(t, data.voltage)
+		xAcceleration.Append(t, data.xAcceleration)
+		yAcceleration.Append(t, data.yAcceleration)
+		zAcceleration.Append(t, data.zAcceleration)
+	}
+	clear := func() {
+		for _, s := range series {
+			s.Clear()
+		}
+		altitudeChart.Refresh()
+		accelerationChart.Refresh()
+	}
+	return altitudeChart, accelerationChart, addSample, clear
+}
+
+// rocketScene shows every connected rocket in one 3D view. Each rocket gets its own launch pad and flight trail and
+// the camera follows the active rocket
+type rocketScene struct {
+	widget  *ThreeDView.ThreeDWidget
+	orbit   *camera.OrbitController
+	rockets map[string]*Rocket
+	trails  map[string]*FlightTrail
+	focused string
+	mu      sync.Mutex
+}
+
+func newRocketScene() *rocketScene {
 	threeDEnv := ThreeDView.NewThreeDWidget()
-	if fyne.CurrentDevice().IsMobile() {
-		threeDEnv.SetFPSCap(30)
-		threeDEnv.SetResolutionFactor(0.3)
-	} else {
-		threeDEnv.SetFPSCap(60)
-		threeDEnv.SetResolutionFactor(0.5)
+	watchViewSettings(threeDEnv)
+
+	scene := &rocketScene{
+		widget:  threeDEnv,
+		rockets: map[string]*Rocket{},
+		trails:  map[string]*FlightTrail{},
+		focused: getProfile().Name,
 	}
+	rocket, _ := scene.rocket(scene.focused)
 
-	rocket := NewTwoStageRocket(types.Point3D{X: 0, Y: 0, Z: 0}, types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0}, threeDEnv)
+	threeDEnv.AddHUDElement(ThreeDView.HUDTopLeft, func() string {
+		return "Altitude: " + getSettings().formatDistance(scene.focusedRocket().GetData().altitude)
+	})
+	threeDEnv.AddHUDElement(ThreeDView.HUDTopLeft, func() string {
+		return "Speed: " + getSettings().formatSpeed(scene.focusedRocket().GetData().speed())
+	})
+	threeDEnv.AddHUDElement(ThreeDView.HUDBottomLeft, func() string {
+		return "Status: " + string(scene.focusedRocket().GetData().status)
+	})
+	threeDEnv.AddHUDElement(ThreeDView.HUDTopRight, func() string {
+		return scene.focusedName()
+	})
+	threeDEnv.SetShowFPS(true)
 	envCamera := camera.NewCamera(types.Point3D{}, types.Rotation3D{})
-	orbitController := camera.NewOrbitController(rocket)
-	orbitController.SetControlsEnabled(false)
-	orbitController.SetRotation(types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0})
-	envCamera.SetController(orbitController)
+	scene.orbit = camera.NewOrbitController(rocket)
+	scene.orbit.SetControlsEnabled(false)
+	scene.orbit.SetRotation(types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0})
+	envCamera.SetController(scene.orbit)
 	threeDEnv.SetCamera(&envCamera)
 
 	updateDistance := func() {
-		orbitController.SetDistance(types.Unit(math.Max(float64(threeDEnv.Size().Width), float64(threeDEnv.Size().Height)) / 2))
+		scene.orbit.SetDistance(types.Unit(math.Max(float64(threeDEnv.Size().Width), float64(threeDEnv.Size().Height)) / 2))
 	}
 
 	threeDEnv.RegisterTickMethod(func() {
 		updateDistance()
 	})
 
-	return threeDEnv, rocket
+	go func() {
+		profileChannel := ps.Sub("profile")
+		for profile := range profileChannel {
+			profile := profile.(RocketProfile)
+			rocket, _ := scene.rocket(profile.Name)
+			rocket.SetModel(profile.Model)
+			scene.focus(profile.Name)
+		}
+	}()
+	go func() {
+		replaySeekChannel := ps.Sub("replaySeek")
+		for seek := range replaySeekChannel {
+			scene.rewind(seek.(ReplaySeek).Played)
+		}
+	}()
+
+	return scene
+}
+
+// rocket returns the rocket and trail of source, adding them next to the other rockets on first use
+func (scene *rocketScene) rocket(source string) (*Rocket, *FlightTrail) {
+	scene.mu.Lock()
+	defer scene.mu.Unlock()
+	if rocket, ok := scene.rockets[source]; ok {
+		return rocket, scene.trails[source]
+	}
+
+	model := ModelTwoStage
+	if profile, ok := findProfile(source); ok {
+		model = profile.Model
+	}
+	origin := types.Point3D{X: types.Unit(len(scene.rockets)) * rocketSpacing}
+	rocket := NewRocket(model, origin, types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0}, scene.widget)
+	trail := NewFlightTrail(origin, scene.widget)
+	scene.rockets[source] = rocket
+	scene.trails[source] = trail
+	return rocket, trail
+}
+
+// rewind reattaches the separated stages of the replayed rocket and rebuilds its trail from the samples the replay has
+// published so far. The live rockets aren't touched
+func (scene *rocketScene) rewind(played Log) {
+	rocket, trail := scene.rocket(replaySource)
+	trail.Clear()
+	rocket.ReattachStage()
+	for _, data := range played {
+		trail.AddSample(data)
+	}
+}
+
+func (scene *rocketScene) focus(source string) {
+	rocket, _ := scene.rocket(source)
+	scene.mu.Lock()
+	scene.focused = source
+	scene.mu.Unlock()
+	scene.orbit.SetTarget(rocket)
+}
+
+func (scene *rocketScene) focusedName() string {
+	scene.mu.Lock()
+	defer scene.mu.Unlock()
+	return scene.focused
+}
+
+func (scene *rocketScene) focusedRocket() *Rocket {
+	rocket, _ := scene.rocket(scene.focusedName())
+	return rocket
 }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"FlightControl/ThreeDView/object"
+	"FlightControl/ThreeDView/types"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	terrainMaxResolution = 40
+	terrainMaxHeight     = 20 * altitudeScale // Elevation of white pixels in a heightmap
+	terrainCellSize      = altitudeScale      // Distance between two grid points of a loaded terrain
+	ringSegments         = 48
+	ringHeight           = types.Unit(5) // Height of the distance rings above the terrain
+	windPolePosition     = -300
+	windPoleHeight       = types.Unit(200)
+	axisLength           = types.Unit(300) // Length of the axes drawn from the top of the launch pad
+)
+
+var (
+	terrainLowColor  = color.RGBA{R: 40, G: 160, B: 40, A: 255}
+	terrainHighColor = color.RGBA{R: 140, G: 110, B: 70, A: 255}
+	ringColor        = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	skyHorizonColor  = color.RGBA{R: 200, G: 225, B: 245, A: 255}
+	skyZenithColor   = color.RGBA{R: 60, G: 120, B: 210, A: 255}
+)
+
+type sceneWidget interface {
+	object.ThreeDWidgetInterface
+	RemoveObject(*object.Object)
+	RemoveLabel(*object.Label)
+}
+
+// LaunchSite holds the environment of the simulation scene: the terrain, the launch pad, distance rings around the
+// pad and a wind indicator
+type LaunchSite struct {
+	widget        sceneWidget
+	terrain       *object.Terrain
+	rings         []*object.Polyline
+	ringLabels    []*object.Label
+	ringDistances []float64 // Radius of the distance rings in meters
+	windPole      *object.Object
+	wind          *object.Object
+	windLabel     *object.Label
+}
+
+func NewLaunchSite(size types.Unit, resolution int, ringDistances []float64, w sceneWidget) *LaunchSite {
+	site := &LaunchSite{
+		widget:        w,
+		terrain:       object.NewFlatTerrain(size, resolution, types.Point3D{}, terrainLowColor, w),
+		ringDistances: ringDistances,
+	}
+	site.buildPad()
+	site.buildAxes()
+	site.buildRings()
+	site.buildWindIndicator()
+	return site
+}
+
+// LoadTerrain replaces the terrain with a heightmap. Files ending in .png are read as grayscale heightmaps,
+// everything else as a text elevation grid in meters
+func (site *LaunchSite) LoadTerrain(name string, reader io.Reader) error {
+	var heights [][]types.Unit
+	var err error
+	if strings.HasSuffix(strings.ToLower(name), ".png") {
+		heights, err = object.LoadHeightmapPNG(reader, terrainMaxHeight, terrainMaxResolution)
+	} else {
+		heights, err = object.LoadElevationGrid(reader)
+		for _, row := range heights {
+			for i := range row {
+				row[i] *= altitudeScale
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	terrain, err := object.NewTerrain(heights, terrainCellSize, types.Point3D{}, terrainLowColor, terrainHighColor, site.widget)
+	if err != nil {
+		return err
+	}
+	// Keep the launch pad on the ground
+	terrain.Position.Z = -terrain.HeightAt(0, 0)
+	site.widget.RemoveObject(site.terrain.Object)
+	site.terrain = terrain
+	site.buildRings()
+	site.placeWindIndicator()
+	return nil
+}
+
+// SetWind points the wind indicator downwind. direction is the bearing the wind blows from, with 0° being +Y
+func (site *LaunchSite) SetWind(direction types.Degrees, speed float64) {
+	site.wind.Rotation = types.Rotation3D{Yaw: -90 - direction}
+	site.windLabel.SetText(fmt.Sprintf("Wind %.1f m/s from %.0f°", speed, float64(direction)))
+}
+
+func (site *LaunchSite) buildPad() {
+	object.NewCube(60, types.Point3D{Z: 15}, types.Rotation3D{}, color.RGBA{R: 90, G: 90, B: 90, A: 255}, site.widget)
+	object.NewCylinder(types.Point3D{X: radius + 4, Z: 140}, types.Rotation3D{}, color.RGBA{R: 60, G: 60, B: 60, A: 255}, site.widget, 250, 2)
+}
+
+// buildAxes draws the X, Y and Z axis of the scene from the top of the launch pad and labels them
+func (site *LaunchSite) buildAxes() {
+	origin := types.Point3D{Z: 30}
+	for i, direction := range []types.Point3D{{X: 1}, {Y: 1}, {Z: 1}} {
+		end := direction
+		end.Scale(axisLength)
+		end.Add(origin)
+		axis := object.NewPolyline(types.Point3D{}, 3, site.widget)
+		axis.SetPoints([]types.Point3D{origin, end}, []color.Color{object.AxisColors[i], object.AxisColors[i]})
+	}
+	object.NewAxisLabels(origin, axisLength, nil, site.widget)
+}
+
+func (site *LaunchSite) buildRings() {
+	for _, ring := range site.rings {
+		site.widget.RemoveObject(ring.Object)
+	}
+	for _, label := range site.ringLabels {
+		site.widget.RemoveLabel(label)
+	}
+	site.rings, site.ringLabels = nil, nil
+
+	width, depth := site.terrain.GetSize()
+	for _, distance := range site.ringDistances {
+		ringRadius := types.Unit(distance) * altitudeScale
+		if ringRadius*2 > max(width, depth) {
+			continue
+		}
+		points := make([]types.Point3D, ringSegments+1)
+		colors := make([]color.Color, ringSegments+1)
+		for i := range points {
+			angle := 2 * math.Pi * float64(i) / ringSegments
+			x, y := ringRadius*types.Unit(math.Cos(angle)), ringRadius*types.Unit(math.Sin(angle))
+			points[i] = types.Point3D{X: x, Y: y, Z: site.terrain.HeightAt(x, y) + ringHeight}
+			colors[i] = ringColor
+		}
+		ring := object.NewPolyline(types.Point3D{}, 3, site.widget)
+		ring.SetPoints(points, colors)
+		site.rings = append(site.rings, ring)
+		site.ringLabels = append(site.ringLabels, object.NewLabel(fmt.Sprintf("%.0f m", distance), points[0], ringColor, site.widget))
+	}
+}
+
+func (site *LaunchSite) buildWindIndicator() {
+	site.windPole = object.NewCylinder(types.Point3D{}, types.Rotation3D{}, color.RGBA{R: 220, G: 220, B: 220, A: 255}, site.widget, windPoleHeight, 2)
+	site.wind = object.NewEmpty(site.widget, types.Point3D{})
+	arrowColor := color.RGBA{R: 255, G: 140, A: 255}
+	// The cylinder and cone point along +Z, so they are pitched to point along +X of the indicator
+	site.wind.AddChild(object.NewCylinder(types.Point3D{}, types.Rotation3D{Pitch: 90}, arrowColor, site.widget, 80, 3))
+	site.wind.AddChild(object.NewCone(types.Point3D{X: 50}, types.Rotation3D{Pitch: 90}, arrowColor, site.widget, 20, 8))
+	site.windLabel = object.NewLabel("", types.Point3D{Z: 30}, color.White, site.widget)
+	site.windLabel.SetAnchor(site.wind)
+	site.placeWindIndicator()
+	site.SetWind(0, 0)
+}
+
+func (site *LaunchSite) placeWindIndicator() {
+	ground := site.terrain.HeightAt(windPolePosition, -windPolePosition)
+	site.windPole.Position = types.Point3D{X: windPolePosition, Y: -windPolePosition, Z: ground + windPoleHeight/2}
+	site.wind.Position = types.Point3D{X: windPolePosition, Y: -windPolePosition, Z: ground + windPoleHeight}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	waterDensity   = 1000.0 // kg/m³
+	airDensity     = 1.225  // kg/m³ at sea level
+	launchPressure = 4e5    // Pa above the ambient pressure the bottle is pumped to
+	nozzleDiameter = 0.021  // m, the opening of a standard bottle
+)
+
+// flightModel simulates the vertical flight of a water rocket from the physical parameters of a RocketProfile.
+// The pressure is assumed to stay at launchPressure until the water is expelled, which overestimates the thrust
+// a little, but keeps the model independent of the bottle volume
+type flightModel struct {
+	dryMass      float64 // kg
+	water        float64 // kg of water left
+	dragArea     float64 // Drag coefficient times the cross-section in m²
+	time         float64 // s since the launch
+	altitude     float64 // m
+	maxAltitude  float64 // m
+	velocity     float64 // m/s, positive upwards
+	acceleration float64 // m/s² measured by the rocket, so 0 in free fall
+	landed       bool
+}
+
+func newFlightModel(profile RocketProfile) *flightModel {
+	radius := profile.Diameter / 2
+	return &flightModel{
+		dryMass:  profile.DryMass,
+		water:    profile.WaterMass,
+		dragArea: profile.DragCoefficient * math.Pi * radius * radius,
+	}
+}
+
+// step advances the flight by dt seconds
+func (model *flightModel) step(dt float64) {
+	if model.landed {
+		return
+	}
+	mass := model.dryMass + model.water
+
+	thrust := 0.0
+	if model.water > 0 {
+		exhaustVelocity := math.Sqrt(2 * launchPressure / waterDensity)
+		massFlow := waterDensity * math.Pi * nozzleDiameter * nozzleDiameter / 4 * exhaustVelocity
+		expelled := math.Min(model.water, massFlow*dt)
+		thrust = expelled / dt * exhaustVelocity
+		model.water -= expelled
+	}
+	drag := -0.5 * airDensity * model.velocity * math.Abs(model.velocity) * model.dragArea
+
+	model.acceleration = (thrust + drag) / mass
+	model.velocity += (model.acceleration - standardGravity) * dt
+	model.altitude += model.velocity * dt
+	model.time += dt
+	model.maxAltitude = math.Max(model.maxAltitude, model.altitude)
+
+	if model.altitude <= 0 && model.velocity <= 0 {
+		// The rocket can't sink into the pad before the launch, and stays where it hit the ground afterwards
+		model.altitude, model.velocity, model.acceleration = 0, 0, standardGravity
+		model.landed = model.water == 0
+	}
+}
+
+func (model *flightModel) status() Status {
+	switch {
+	case model.landed:
+		return StatusLanded
+	case model.water > 0:
+		return StatusPoweredAscent
+	case model.velocity > 0:
+		return StatusUnpoweredAscent
+	default:
+		return StatusDescent
+	}
+}
+
+// data returns the state of the flight as a telemetry sample
+func (model *flightModel) data() Data {
+	return Data{
+		timestamp:     strconv.FormatFloat(model.time, 'f', 3, 64),
+		status:        model.status(),
+		altitude:      model.altitude,
+		maxAltitude:   model.maxAltitude,
+		zAcceleration: model.acceleration,
+		zVelocity:     model.velocity,
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+)
+
+// simulateFlight runs a flight of profile until it lands and returns the apogee and the phases in the order they
+// were reached
+func simulateFlight(t *testing.T, profile RocketProfile) (float64, []Status) {
+	t.Helper()
+	model := newFlightModel(profile)
+	var phases []Status
+	for i := 0; i < 60*simulationTPS; i++ {
+		model.step(1.0 / simulationTPS)
+		if status := model.status(); len(phases) == 0 || phases[len(phases)-1] != status {
+			phases = append(phases, status)
+		}
+		if model.landed {
+			return model.maxAltitude, phases
+		}
+	}
+	t.Fatal("the rocket didn't land within a minute")
+	return 0, nil
+}
+
+func TestFlightModelPhases(t *testing.T) {
+	apogee, phases := simulateFlight(t, newRocketProfile("Test", Settings{}))
+	expected := []Status{StatusPoweredAscent, StatusUnpoweredAscent, StatusDescent, StatusLanded}
+	if len(phases) != len(expected) {
+		t.Fatalf("phases %v, expected %v", phases, expected)
+	}
+	for i := range expected {
+		if phases[i] != expected[i] {
+			t.Fatalf("phases %v, expected %v", phases, expected)
+		}
+	}
+	if apogee < 10 || apogee > 100 {
+		t.Errorf("apogee %.1f m isn't plausible for a water rocket", apogee)
+	}
+}
+
+func TestFlightModelParameters(t *testing.T) {
+	base := newRocketProfile("Test", Settings{})
+	baseApogee, _ := simulateFlight(t, base)
+
+	tests := []struct {
+		name   string
+		change func(*RocketProfile)
+		higher bool
+	}{
+		{"heavier", func(profile *RocketProfile) { profile.DryMass *= 2 }, false},
+		{"lighter", func(profile *RocketProfile) { profile.DryMass /= 2 }, true},
+		{"more drag", func(profile *RocketProfile) { profile.DragCoefficient *= 2 }, false},
+		{"wider", func(profile *RocketProfile) { profile.Diameter *= 2 }, false},
+		{"more water", func(profile *RocketProfile) { profile.WaterMass *= 1.5 }, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := base
+			test.change(&profile)
+			apogee, _ := simulateFlight(t, profile)
+			if higher := apogee > baseApogee; higher != test.higher {
+				t.Errorf("apogee %.1f m compared to %.1f m", apogee, baseApogee)
+			}
+		})
+	}
+}
+
+func TestFlightModelWithoutWater(t *testing.T) {
+	profile := newRocketProfile("Test", Settings{})
+	profile.WaterMass = 0
+	apogee, phases := simulateFlight(t, profile)
+	if apogee != 0 || len(phases) != 1 || phases[0] != StatusLanded {
+		t.Errorf("apogee %.1f m and phases %v, expected the rocket to stay on the pad", apogee, phases)
+	}
+}
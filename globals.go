@@ -3,5 +3,3 @@ package main
 import "github.com/cskr/pubsub"
 
 var ps = pubsub.New(0)
-
-var currentLog Log
@@ -5,8 +5,10 @@ go 1.23.1
 require (
 	fyne.io/fyne/v2 v2.5.3
 	github.com/cskr/pubsub v1.0.2
+	golang.org/x/image v0.21.0
 	golang.org/x/net v0.29.0
 	gonum.org/v1/plot v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -40,9 +42,7 @@ require (
 	github.com/srwiley/rasterx v0.0.0-20220730225603-2ab79fcdd4ef // indirect
 	github.com/stretchr/testify v1.9.0 // indirect
 	github.com/yuin/goldmark v1.7.4 // indirect
-	golang.org/x/image v0.21.0 // indirect
 	golang.org/x/mobile v0.0.0-20240909163608-642950227fb3 // indirect
 	golang.org/x/sys v0.26.0 // indirect
 	golang.org/x/text v0.19.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	linkStatsWindow   = 5 * time.Second // Time the receive rate and clock offset are computed over
+	linkStatsInterval = time.Second     // Time between two published and recorded statistics
+	linkGapFactor     = 3               // Inter-arrival times above this multiple of the usual interval count as gap
+)
+
+// LinkStats describes the quality of the telemetry link to one rocket. Loss is counted from sequence numbers for binary
+// frames and estimated from gaps in the arrival times otherwise
+type LinkStats struct {
+	Source      string        `json:"source"`
+	Received    int           `json:"received"`
+	Rate        float64       `json:"rate"` // Frames per second in the last linkStatsWindow
+	Gaps        int           `json:"gaps"`
+	Lost        int           `json:"lost"`
+	Errors      int           `json:"errors"`  // Frames that couldn't be parsed, e.g. because of a checksum mismatch
+	Flagged     int           `json:"flagged"` // Samples the validation found problems in
+	Jitter      time.Duration `json:"jitter"`
+	ClockOffset time.Duration `json:"clockOffset"` // Smallest difference between ground and rocket clock in the window
+	Latency     time.Duration `json:"latency"`     // Delay of the last frame compared to the fastest one in the window
+	LastFrame   time.Time     `json:"lastFrame"`
+	HasClock    bool          `json:"hasClock"` // Whether the frames have timestamps
+	Precise     bool          `json:"precise"`  // Whether the timestamps are fine enough for latency and jitter
+}
+
+type linkArrival struct {
+	received time.Time
+	transit  time.Duration
+	hasClock bool
+	precise  bool
+}
+
+// linkMonitor computes the LinkStats of one connection from the frames it observes
+type linkMonitor struct {
+	stats        LinkStats
+	arrivals     []linkArrival
+	interval     time.Duration // Smoothed usual time between two frames
+	lastSequence uint32
+	hasSequence  bool
+}
+
+func newLinkMonitor(source string) *linkMonitor {
+	return &linkMonitor{stats: LinkStats{Source: source}}
+}
+
+// newSession forgets the last sequence number, since a rocket starts counting again after reconnecting
+func (monitor *linkMonitor) newSession() {
+	monitor.hasSequence = false
+}
+
+func (monitor *linkMonitor) observe(received time.Time, parser *TelemetryParser, data Data) {
+	stats := &monitor.stats
+	arrival := linkArrival{received: received}
+	if rocketTime, ok := parser.RocketTime(data); ok {
+		arrival.transit = time.Duration(received.UnixNano()) - rocketTime
+		arrival.hasClock = true
+		arrival.precise = monitor.interval > 0 && parser.TimestampResolution() <= monitor.interval/2
+	}
+
+	if len(monitor.arrivals) > 0 {
+		previous := monitor.arrivals[len(monitor.arrivals)-1]
+		interArrival := received.Sub(previous.received)
+
+		if sequence, ok := parser.Sequence(); ok {
+			// The subtraction wraps around with the sequence number, duplicated and reordered frames give a negative gap
+			if gap := int32(sequence - monitor.lastSequence); monitor.hasSequence && gap > 1 {
+				stats.Gaps++
+				stats.Lost += int(gap) - 1
+			}
+		} else if monitor.interval > 0 && interArrival > linkGapFactor*monitor.interval {
+			stats.Gaps++
+			stats.Lost += int(math.Round(float64(interArrival)/float64(monitor.interval))) - 1
+		}
+
+		if monitor.interval == 0 {
+			monitor.interval = interArrival
+		} else if interArrival <= linkGapFactor*monitor.interval {
+			monitor.interval += (interArrival - monitor.interval) / 16
+		}
+
+		// Inter-arrival jitter as in RFC 3550, using the rocket clock if frames carry usable timestamps
+		deviation := interArrival - monitor.interval
+		if arrival.precise && previous.precise {
+			deviation = arrival.transit - previous.transit
+		}
+		stats.Jitter += (time.Duration(math.Abs(float64(deviation))) - stats.Jitter) / 16
+	}
+	if sequence, ok := parser.Sequence(); ok && (!monitor.hasSequence || int32(sequence-monitor.lastSequence) > 0) {
+		monitor.lastSequence = sequence
+		monitor.hasSequence = true
+	}
+
+	monitor.arrivals = append(monitor.arrivals, arrival)
+	stats.Received++
+	if data.quality != 0 {
+		stats.Flagged++
+	}
+	stats.LastFrame = received
+	monitor.update(received)
+
+	stats.HasClock = arrival.hasClock
+	stats.Precise = arrival.precise
+	if arrival.precise {
+		stats.Latency = arrival.transit - stats.ClockOffset
+	}
+}
+
+// update drops arrivals that left the window and recomputes the windowed statistics
+func (monitor *linkMonitor) update(now time.Time) {
+	start := 0
+	for start < len(monitor.arrivals) && now.Sub(monitor.arrivals[start].received) > linkStatsWindow {
+		start++
+	}
+	monitor.arrivals = monitor.arrivals[start:]
+
+	monitor.stats.Rate = float64(len(monitor.arrivals)) / linkStatsWindow.Seconds()
+	offset := time.Duration(math.MaxInt64)
+	for _, arrival := range monitor.arrivals {
+		if arrival.hasClock && arrival.transit < offset {
+			offset = arrival.transit
+		}
+	}
+	if offset != math.MaxInt64 {
+		monitor.stats.ClockOffset = offset
+	}
+}
+
+func (monitor *linkMonitor) snapshot(now time.Time) LinkStats {
+	monitor.update(now)
+	return monitor.stats
+}
+
+func (stats LinkStats) lossRatio() float64 {
+	if stats.Received+stats.Lost == 0 {
+		return 0
+	}
+	return float64(stats.Lost) / float64(stats.Received+stats.Lost)
+}
+
+func (stats LinkStats) describe(now time.Time) string {
+	description := fmt.Sprintf("Rate: %.1f frames/s\nReceived: %d  Gaps: %d  Lost: %d (%.1f %%)  Errors: %d  Flagged: %d\nJitter: %s",
+		stats.Rate, stats.Received, stats.Gaps, stats.Lost, stats.lossRatio()*100, stats.Errors, stats.Flagged, stats.Jitter.Round(time.Microsecond))
+	if stats.HasClock {
+		description += fmt.Sprintf("\nClock offset: %s", stats.ClockOffset.Round(time.Millisecond))
+	}
+	if stats.Precise {
+		description += fmt.Sprintf("  Latency: %s", stats.Latency.Round(time.Millisecond))
+	}
+	if !stats.LastFrame.IsZero() {
+		description += fmt.Sprintf("\nLast frame: %s ago", now.Sub(stats.LastFrame).Round(100*time.Millisecond))
+	}
+	return description
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var linkTestStart = time.Unix(1000, 0)
+
+// linkFrame is a frame the monitor observes, arriving at the given time after linkTestStart
+type linkFrame struct {
+	arrival time.Duration
+	frame   string
+}
+
+func binaryLinkFrame(rocketTime time.Duration, sequence uint32) string {
+	data := Data{timestamp: strconv.FormatInt(rocketTime.Milliseconds(), 10), status: StatusIdle}
+	return binaryFramePrefix + base64.StdEncoding.EncodeToString(encodeBinaryFrame(data, sequence))
+}
+
+// legacyLinkFrames returns legacy frames without sequence numbers arriving at the given times
+func legacyLinkFrames(arrivals ...time.Duration) []linkFrame {
+	frames := make([]linkFrame, len(arrivals))
+	for i, arrival := range arrivals {
+		frames[i] = linkFrame{arrival, legacyFrame(map[string]string{"timestamp": strconv.Itoa(i + 1)})}
+	}
+	return frames
+}
+
+// regularArrivals returns count arrival times starting at start, interval apart
+func regularArrivals(start time.Duration, count int, interval time.Duration) []time.Duration {
+	arrivals := make([]time.Duration, count)
+	for i := range arrivals {
+		arrivals[i] = start + time.Duration(i)*interval
+	}
+	return arrivals
+}
+
+func observeFrames(t *testing.T, frames []linkFrame) LinkStats {
+	t.Helper()
+	monitor := newLinkMonitor("test")
+	parser := newTelemetryParser()
+	for _, frame := range frames {
+		data, ok, err := parser.Parse(frame.frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			monitor.observe(linkTestStart.Add(frame.arrival), parser, data)
+		}
+	}
+	return monitor.stats
+}
+
+func TestLinkLoss(t *testing.T) {
+	sequenced := func(sequences ...uint32) []linkFrame {
+		frames := make([]linkFrame, len(sequences))
+		for i, sequence := range sequences {
+			arrival := time.Duration(i) * 100 * time.Millisecond
+			frames[i] = linkFrame{arrival, binaryLinkFrame(arrival, sequence)}
+		}
+		return frames
+	}
+
+	tests := []struct {
+		name   string
+		frames []linkFrame
+		gaps   int
+		lost   int
+	}{
+		{"no loss", sequenced(1, 2, 3, 4), 0, 0},
+		{"sequence gap", sequenced(1, 2, 5, 6), 1, 2},
+		{"two sequence gaps", sequenced(1, 3, 4, 8), 2, 4},
+		{"sequence wraps around", sequenced(math.MaxUint32-1, math.MaxUint32, math.MaxUint32, 0, 1), 0, 0},
+		{"gap across the wraparound", sequenced(math.MaxUint32-1, math.MaxUint32, 2), 1, 2},
+		{"duplicated and reordered frames", sequenced(1, 2, 2, 4, 3, 5), 1, 1},
+		{"regular arrivals without sequence numbers", legacyLinkFrames(regularArrivals(0, 10, 100*time.Millisecond)...), 0, 0},
+		{
+			"arrival gap without sequence numbers",
+			legacyLinkFrames(append(regularArrivals(0, 6, 100*time.Millisecond), 900*time.Millisecond, time.Second)...),
+			1, 3,
+		},
+		{
+			"short delay without sequence numbers",
+			legacyLinkFrames(append(regularArrivals(0, 6, 100*time.Millisecond), 750*time.Millisecond)...),
+			0, 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats := observeFrames(t, test.frames)
+			if stats.Received != len(test.frames) {
+				t.Errorf("received %d, expected %d", stats.Received, len(test.frames))
+			}
+			if stats.Gaps != test.gaps || stats.Lost != test.lost {
+				t.Errorf("%d gaps and %d lost, expected %d and %d", stats.Gaps, stats.Lost, test.gaps, test.lost)
+			}
+		})
+	}
+}
+
+func TestLinkJitter(t *testing.T) {
+	alternating := make([]time.Duration, 200)
+	for i := 1; i < len(alternating); i++ {
+		alternating[i] = alternating[i-1] + 80*time.Millisecond + time.Duration(i%2)*40*time.Millisecond
+	}
+	// Frames sent exactly when they arrive have a constant transit time, so the rocket clock shows no jitter
+	var constantTransit []linkFrame
+	for i, arrival := range alternating {
+		constantTransit = append(constantTransit, linkFrame{arrival, binaryLinkFrame(arrival, uint32(i))})
+	}
+
+	tests := []struct {
+		name     string
+		frames   []linkFrame
+		min, max time.Duration
+	}{
+		{"regular arrivals", legacyLinkFrames(regularArrivals(0, 200, 100*time.Millisecond)...), 0, 0},
+		{"alternating arrivals", legacyLinkFrames(alternating...), 15 * time.Millisecond, 25 * time.Millisecond},
+		{"alternating arrivals with constant transit", constantTransit, 0, time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats := observeFrames(t, test.frames)
+			if stats.Jitter < test.min || stats.Jitter > test.max {
+				t.Errorf("jitter %s, expected between %s and %s", stats.Jitter, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestLinkClockOffset(t *testing.T) {
+	// The rocket clock starts at the ground time linkTestStart and frames take between 10 and 50 ms to arrive
+	delays := []time.Duration{30, 10, 50, 20, 40}
+	var frames []linkFrame
+	for i, delay := range delays {
+		sent := time.Duration(i) * 100 * time.Millisecond
+		frames = append(frames, linkFrame{sent + delay*time.Millisecond, binaryLinkFrame(sent, uint32(i))})
+	}
+	stats := observeFrames(t, frames)
+
+	expectedOffset := time.Duration(linkTestStart.UnixNano()) + 10*time.Millisecond
+	if stats.ClockOffset != expectedOffset {
+		t.Errorf("clock offset %s, expected %s", stats.ClockOffset, expectedOffset)
+	}
+	if !stats.HasClock || !stats.Precise {
+		t.Errorf("has clock %v and precise %v, expected both", stats.HasClock, stats.Precise)
+	}
+	if stats.Latency != 30*time.Millisecond {
+		t.Errorf("latency %s, expected 30ms", stats.Latency)
+	}
+
+	// Legacy frames only have timestamps in seconds, which is too coarse for the latency
+	stats = observeFrames(t, legacyLinkFrames(regularArrivals(0, 5, 100*time.Millisecond)...))
+	if !stats.HasClock || stats.Precise {
+		t.Errorf("has clock %v and precise %v, expected only a clock", stats.HasClock, stats.Precise)
+	}
+}
@@ -4,26 +4,30 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/widget"
 )
 
 func main() {
 	App := app.NewWithID("com.virusrpi.flightcontrol")
 	App.Settings().SetTheme(&FlightControlTheme{})
+	initSettings(App)
+	initProfiles(App)
 	go func() { initWebsocket(App) }()
+	go watchConnectionSettings(App)
 	MainWindow := App.NewWindow("Flight Control")
 	MainWindow.Resize(fyne.NewSize(800, 600))
 	MainWindow.CenterOnScreen()
 
 	tabControl := container.NewTabItem("Control", controlTab(App, MainWindow))
 	tabAnalysis := container.NewTabItem("Analysis", analysisTab())
-	tabSimulation := container.NewTabItem("Simulation", simulationTab())
-	tabSetting := container.NewTabItem("Settings", widget.NewLabel("Content of Tab 4"))
-	tabChecklists := container.NewTabItem("Checklists", widget.NewLabel("Content of Tab 5"))
+	tabSimulation := container.NewTabItem("Simulation", simulationTab(MainWindow))
+	tabSetting := container.NewTabItem("Settings", settingsTab(App, MainWindow))
+	tabChecklists := container.NewTabItem("Checklists", checklistsTab(App, MainWindow))
 	tabMock := container.NewTabItem("Mock", mockTab())
 
-	tabs := container.NewAppTabs(tabControl, tabAnalysis, tabSimulation, tabSetting, tabChecklists, tabMock)
+	var tabs *container.AppTabs
+	tabSessions := container.NewTabItem("Sessions", sessionsTab(App, MainWindow, func() { tabs.Select(tabAnalysis) }, func() { tabs.Select(tabControl) }))
+
+	tabs = container.NewAppTabs(tabControl, tabAnalysis, tabSessions, tabSimulation, tabSetting, tabChecklists, tabMock)
 
 	tabs.OnSelected = func(item *container.TabItem) {
 		ps.Pub(item, "selectedTab")
@@ -33,19 +37,8 @@ func main() {
 		fyne.NewMenu("File",
 			fyne.NewMenuItem("Load log", func() { println("Load log") }),
 			fyne.NewMenuItem("Export log", func() { println("Export log") }),
-			fyne.NewMenuItem("Set WaRa IP", func() {
-				ipEntry := widget.NewEntry()
-				ipEntry.SetPlaceHolder("Enter IP")
-				dialog.ShowForm("Set WaRa IP", "OK", "Cancel", []*widget.FormItem{
-					widget.NewFormItem("IP", ipEntry),
-				}, func(ok bool) {
-					if !ok {
-						return
-					}
-					App.Preferences().SetString("WaRaIP", ipEntry.Text)
-					updateWebsocket(App)
-				}, MainWindow)
-			}),
+			fyne.NewMenuItem("Export replay", func() { showReplayExportDialog(MainWindow) }),
+			fyne.NewMenuItem("Set WaRa IP", func() { showRocketHostDialog(App, MainWindow) }),
 		),
 		fyne.NewMenu("Options",
 			fyne.NewMenuItem("Toggle fullscreen", func() { MainWindow.SetFullScreen(!MainWindow.FullScreen()) }),
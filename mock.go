@@ -9,6 +9,7 @@ import (
 	"log"
 	"math/rand/v2"
 	"net/http"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -32,7 +33,15 @@ func mockTab() fyne.CanvasObject {
 		serverStatusLabel.SetText("Server status: running")
 	})
 
-	serverStartStopContainer := container.NewVBox(startServerButton, serverStatusLabel)
+	throughputLabel := widget.NewLabel("Throughput: N/A")
+	go func() {
+		for range time.Tick(time.Second) {
+			frames, bytes := mockServer.throughput()
+			throughputLabel.SetText(fmt.Sprintf("Throughput: %d frames/s, %.1f kB/s", frames, float64(bytes)/1024))
+		}
+	}()
+
+	serverStartStopContainer := container.NewVBox(startServerButton, serverStatusLabel, throughputLabel)
 
 	statusSelector := widget.NewSelect([]string{
 		"Idle",
@@ -84,12 +93,19 @@ func mockTab() fyne.CanvasObject {
 }
 
 type MockServer struct {
-	running   bool
-	ip        string
-	mu        sync.Mutex
-	data      Data
-	clients   map[*websocket.Conn]bool
-	broadcast chan Data
+	running    bool
+	ip         string
+	mu         sync.Mutex
+	data       Data
+	clients    map[*websocket.Conn]*mockClient
+	broadcast  chan Data
+	framesSent int
+	bytesSent  int
+}
+
+type mockClient struct {
+	binary   bool
+	sequence uint32
 }
 
 func (s *MockServer) start() {
@@ -100,7 +116,7 @@ func (s *MockServer) start() {
 	defer s.mu.Unlock()
 	mockLogger.Println("Starting mock server...")
 	s.running = true
-	s.clients = make(map[*websocket.Conn]bool)
+	s.clients = make(map[*websocket.Conn]*mockClient)
 	s.broadcast = make(chan Data)
 
 	http.HandleFunc("/websocket", s.handleWebsocket)
@@ -113,7 +129,11 @@ func (s *MockServer) start() {
 }
 
 func (s *MockServer) handleWebsocket(w http.ResponseWriter, r *http.Request) {
-	websocket.Handler(func(conn *websocket.Conn) {
+	handshake := func(config *websocket.Config, r *http.Request) error {
+		config.Protocol = negotiateSubprotocol(config.Protocol)
+		return nil
+	}
+	websocket.Server{Handshake: handshake, Handler: func(conn *websocket.Conn) {
 		mockLogger.Println("New WebSocket connection from", conn.RemoteAddr())
 		defer func() {
 			s.mu.Lock()
@@ -125,12 +145,29 @@ func (s *MockServer) handleWebsocket(w http.ResponseWriter, r *http.Request) {
 			}
 		}()
 
+		client := &mockClient{binary: slices.Contains(conn.Config().Protocol, SubprotocolBinary)}
+		if !client.binary {
+			if _, err := conn.Write([]byte(legacySchema.frame())); err != nil {
+				mockLogger.Println("Error sending schema frame:", err)
+				return
+			}
+		}
+
 		s.mu.Lock()
-		s.clients[conn] = true
+		s.clients[conn] = client
 		s.mu.Unlock()
 
 		select {}
-	}).ServeHTTP(w, r)
+	}}.ServeHTTP(w, r)
+}
+
+// throughput returns the number of frames and bytes sent since the last call
+func (s *MockServer) throughput() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	frames, bytes := s.framesSent, s.bytesSent
+	s.framesSent, s.bytesSent = 0, 0
+	return frames, bytes
 }
 
 func (s *MockServer) sendMockData() {
@@ -143,8 +180,9 @@ func (s *MockServer) sendMockData() {
 		}
 		s.mu.Unlock()
 
+		timestamp := time.Now().Unix()
 		dataString := fmt.Sprintf("%d,%f,%f,%d,%f,%f,%f,%f,%f,%f,%f,%f,%f,%f,%f,%f,%f",
-			time.Now().Unix(),
+			timestamp,
 			s.data.altitude,
 			s.data.maxAltitude,
 			s.data.status.toIndex(),
@@ -164,16 +202,25 @@ func (s *MockServer) sendMockData() {
 		)
 
 		s.mu.Lock()
-		for client := range s.clients {
-			_, err := client.Write([]byte(dataString))
+		for client, state := range s.clients {
+			message := telemetryMessage{payload: []byte(dataString)}
+			if state.binary {
+				data := s.data
+				data.timestamp = strconv.FormatInt(timestamp*1000, 10)
+				message = telemetryMessage{payload: encodeBinaryFrame(data, state.sequence), binary: true}
+				state.sequence++
+			}
+			err := telemetryCodec.Send(client, message)
 			if err != nil {
 				mockLogger.Println(err)
-				err := client.Close()
-				if err != nil {
-					return
+				if err := client.Close(); err != nil {
+					mockLogger.Println("Error closing client connection:", err)
 				}
 				delete(s.clients, client)
+				continue
 			}
+			s.framesSent++
+			s.bytesSent += len(message.payload)
 		}
 		s.mu.Unlock()
 
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"fyne.io/fyne/v2"
 	"golang.org/x/net/websocket"
 	"io"
@@ -11,71 +12,275 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-var ws *websocket.Conn
-var done chan struct{}
 var netLogger = log.New(log.Writer(), "[Networking] ", log.LstdFlags)
 
+const connectedRocketsKey = "ConnectedRockets"
+
+// RocketConnection is the WebSocket connection to one rocket. Every sample received on it is tagged with the name of
+// the rocket's profile as its source
+type RocketConnection struct {
+	app      fyne.App
+	profile  RocketProfile
+	encoding string
+	monitor  *linkMonitor
+	ws       *websocket.Conn
+	closed   bool
+	mu       sync.Mutex
+}
+
+var (
+	connections   = map[string]*RocketConnection{}
+	connectionsMu sync.Mutex
+)
+
 func initWebsocket(App fyne.App) {
-	wsUrl := url.URL{Scheme: "ws", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/websocket"}
-	netLogger.Println("Connecting to " + wsUrl.String())
+	syncConnections(App)
+}
 
-	var err error
-	for {
-		ws, err = websocket.Dial(wsUrl.String(), "", "http://localhost/")
-		if err == nil {
-			break
+func watchConnectionSettings(App fyne.App) {
+	for range ps.Sub("settings", "profile") {
+		syncConnections(App)
+	}
+}
+
+// primaryProfile returns the active profile with the connection info of the settings, which can be newer than the
+// profile while a change is being published
+func primaryProfile() RocketProfile {
+	profile := getProfile()
+	settings := getSettings()
+	profile.RocketHost = settings.RocketHost
+	profile.BaseStationHost = settings.BaseStationHost
+	profile.WebSocketPath = settings.WebSocketPath
+	return profile
+}
+
+// rocketProfile returns the profile that commands to the rocket called name are sent with. The active rocket is
+// reached with the connection info of the settings
+func rocketProfile(name string) (RocketProfile, bool) {
+	if name == getProfile().Name {
+		return primaryProfile(), true
+	}
+	return findProfile(name)
+}
+
+func extraRockets(App fyne.App) []string {
+	return App.Preferences().StringList(connectedRocketsKey)
+}
+
+func setExtraRockets(App fyne.App, names []string) {
+	App.Preferences().SetStringList(connectedRocketsKey, names)
+	syncConnections(App)
+}
+
+// syncConnections connects to the active rocket and the extra rockets and closes all other connections.
+// Connections whose host or path changed are reopened
+func syncConnections(App fyne.App) {
+	desired := map[string]RocketProfile{}
+	for _, name := range extraRockets(App) {
+		if profile, ok := findProfile(name); ok {
+			desired[name] = profile
+		}
+	}
+	primary := primaryProfile()
+	desired[primary.Name] = primary
+
+	encoding := getSettings().TelemetryEncoding
+	connectionsMu.Lock()
+	defer connectionsMu.Unlock()
+	for name, connection := range connections {
+		profile, ok := desired[name]
+		current := connection.getProfile()
+		if ok && profile.RocketHost == current.RocketHost && profile.WebSocketPath == current.WebSocketPath && connection.encoding == encoding {
+			connection.setProfile(profile)
+			continue
+		}
+		connection.close()
+		delete(connections, name)
+	}
+	for name, profile := range desired {
+		if _, ok := connections[name]; ok || profile.RocketHost == "" {
+			continue
 		}
-		netLogger.Println("WebSocket connection failed, retrying in 5 seconds...")
-		time.Sleep(5 * time.Second)
+		connection := &RocketConnection{app: App, profile: profile, encoding: encoding, monitor: newLinkMonitor(name)}
+		connections[name] = connection
+		go connection.run()
 	}
+}
 
-	done = make(chan struct{})
+func (connection *RocketConnection) getProfile() RocketProfile {
+	connection.mu.Lock()
+	defer connection.mu.Unlock()
+	return connection.profile
+}
 
-	go func() {
-		defer func() {
-			close(done)
-			if err := ws.Close(); err != nil {
-				netLogger.Println("Error closing WebSocket:", err)
-			}
-		}()
-
-		for {
-			var msg string
-			err := websocket.Message.Receive(ws, &msg)
-			if err != nil {
-				if err == io.EOF {
-					netLogger.Println("WebSocket read error: EOF")
-				} else {
-					netLogger.Println("WebSocket read error:", err)
-				}
-				return
-			}
-			//netLogger.Printf("Received: %s\n", msg)
+func (connection *RocketConnection) setProfile(profile RocketProfile) {
+	connection.mu.Lock()
+	connection.profile = profile
+	connection.mu.Unlock()
+}
 
-			var newestData Data
-			parseCSVData(msg, &newestData)
-			ps.Pub(newestData, "newData")
+func (connection *RocketConnection) isClosed() bool {
+	connection.mu.Lock()
+	defer connection.mu.Unlock()
+	return connection.closed
+}
 
-			updateVoltage(newestData.voltage)
-			updateStatus(string(newestData.status))
-			updateHeight(newestData.altitude)
-			updateMaxHeight(newestData.maxAltitude)
+func (connection *RocketConnection) close() {
+	connection.mu.Lock()
+	defer connection.mu.Unlock()
+	connection.closed = true
+	if connection.ws != nil {
+		if err := connection.ws.Close(); err != nil {
+			netLogger.Println("Error closing WebSocket:", err)
 		}
-	}()
+	}
+}
+
+func (connection *RocketConnection) run() {
+	for connection.dial() {
+		recorder, err := newSessionRecorder(connection.app, connection.getProfile())
+		if err != nil {
+			netLogger.Println("Not recording session:", err)
+		}
+		done := make(chan struct{})
+		go connection.publishLinkStats(recorder, done)
+		connection.receive(recorder)
+		close(done)
+		if recorder != nil {
+			if err := recorder.close(); err != nil {
+				netLogger.Println("Error closing session recording:", err)
+			}
+		}
+		if connection.isClosed() {
+			return
+		}
+		netLogger.Println("Lost connection to " + connection.getProfile().Name + ", reconnecting")
+	}
 }
 
-func updateWebsocket(App fyne.App) {
-	if ws != nil {
-		err := ws.Close()
+func (connection *RocketConnection) dial() bool {
+	for attempt := 1; ; attempt++ {
+		if connection.isClosed() {
+			return false
+		}
+		settings := getSettings()
+		profile := connection.getProfile()
+		wsUrl := url.URL{Scheme: "ws", Host: profile.RocketHost, Path: profile.WebSocketPath}
+		netLogger.Println("Connecting to " + profile.Name + " at " + wsUrl.String())
+
+		config, err := websocket.NewConfig(wsUrl.String(), "http://localhost/")
 		if err != nil {
 			netLogger.Println(err)
+			return false
+		}
+		if connection.encoding == TelemetryEncodingBinary {
+			config.Protocol = []string{SubprotocolBinary, SubprotocolCSV}
+		}
+		ws, err := websocket.DialConfig(config)
+		if err == nil {
+			if protocols := ws.Config().Protocol; len(protocols) == 1 {
+				netLogger.Println(profile.Name + " uses subprotocol " + protocols[0])
+			}
+			connection.mu.Lock()
+			defer connection.mu.Unlock()
+			if connection.closed {
+				ws.Close()
+				return false
+			}
+			connection.ws = ws
+			return true
+		}
+		if settings.ReconnectAttempts > 0 && attempt >= settings.ReconnectAttempts {
+			netLogger.Printf("WebSocket connection to %s failed %d times, giving up\n", profile.Name, attempt)
+			return false
+		}
+		netLogger.Printf("WebSocket connection to %s failed, retrying in %s...\n", profile.Name, settings.reconnectInterval())
+		time.Sleep(settings.reconnectInterval())
+	}
+}
+
+func (connection *RocketConnection) receive(recorder *sessionRecorder) {
+	defer func() {
+		connection.mu.Lock()
+		defer connection.mu.Unlock()
+		if err := connection.ws.Close(); err != nil && !connection.closed {
+			netLogger.Println("Error closing WebSocket:", err)
+		}
+	}()
+
+	connection.mu.Lock()
+	connection.monitor.newSession()
+	connection.mu.Unlock()
+
+	parser := newTelemetryParser()
+	for {
+		var message telemetryMessage
+		err := telemetryCodec.Receive(connection.ws, &message)
+		if err != nil {
+			if err == io.EOF {
+				netLogger.Println("WebSocket read error: EOF")
+			} else if !connection.isClosed() {
+				netLogger.Println("WebSocket read error:", err)
+			}
 			return
 		}
+		received := time.Now()
+		msg := string(message.payload)
+		if message.binary {
+			msg = binaryFrameText(message.payload)
+		}
+		if recorder != nil {
+			if err := recorder.record(received, msg); err != nil {
+				netLogger.Println("Error recording session:", err)
+			}
+		}
+
+		profile := connection.getProfile()
+		newestData, ok, err := parser.Parse(msg)
+		if err != nil {
+			netLogger.Println("Dropping frame from "+profile.Name+":", err)
+			connection.mu.Lock()
+			connection.monitor.stats.Errors++
+			connection.mu.Unlock()
+			continue
+		}
+		if !ok {
+			netLogger.Printf("%s uses telemetry schema %d\n", profile.Name, parser.Schema().Version)
+			continue
+		}
+		connection.mu.Lock()
+		connection.monitor.observe(received, parser, newestData)
+		connection.mu.Unlock()
+		newestData = profile.Calibration.apply(newestData)
+		newestData.source = profile.Name
+		ps.Pub(newestData, "newData")
+	}
+}
+
+// publishLinkStats publishes the link statistics on "linkStats" and records them with the session until done is closed
+func (connection *RocketConnection) publishLinkStats(recorder *sessionRecorder, done chan struct{}) {
+	ticker := time.NewTicker(linkStatsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			connection.mu.Lock()
+			stats := connection.monitor.snapshot(now)
+			connection.mu.Unlock()
+			if recorder != nil {
+				if err := recorder.recordStats(stats); err != nil {
+					netLogger.Println("Error recording link statistics:", err)
+				}
+			}
+			ps.Pub(stats, "linkStats")
+		}
 	}
-	initWebsocket(App)
 }
 
 func post(postUrl url.URL) {
@@ -93,11 +298,20 @@ func post(postUrl url.URL) {
 }
 
 func get(getUrl url.URL) string {
-	response, err := http.Get(getUrl.String())
+	body, err := fetch(getUrl)
 	if err != nil {
 		netLogger.Println(err)
 		return ""
 	}
+	return body
+}
+
+// fetch is get for callers that have to tell a failed request apart from an empty response
+func fetch(getUrl url.URL) (string, error) {
+	response, err := http.Get(getUrl.String())
+	if err != nil {
+		return "", err
+	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
@@ -107,90 +321,101 @@ func get(getUrl url.URL) string {
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		netLogger.Println(err)
-		return ""
+		return "", err
 	}
 
-	return string(body)
+	return string(body), nil
 }
 
-func reset(App fyne.App) {
-	resetUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset"}
+func reset(profile RocketProfile) {
+	resetUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset"}
 	post(resetUrl)
 }
 
-func deployParachute(App fyne.App) {
-	deployParachuteUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/deploy/parachute"}
+func deployParachute(profile RocketProfile) {
+	deployParachuteUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/deploy/parachute"}
 	post(deployParachuteUrl)
 }
 
-func deployStage(App fyne.App) {
-	deployStageUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/deploy/stage"}
+func deployStage(profile RocketProfile) {
+	deployStageUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/deploy/stage"}
 	post(deployStageUrl)
 }
 
-func startLogging(App fyne.App) {
-	startLoggingUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/log/start"}
+func startLogging(profile RocketProfile) {
+	startLoggingUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/log/start"}
 	post(startLoggingUrl)
 }
 
-func stopLogging(App fyne.App) {
-	stopLoggingUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/log/stop"}
+func stopLogging(profile RocketProfile) {
+	stopLoggingUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/log/stop"}
 	post(stopLoggingUrl)
 }
 
-func recalibrateGyro(App fyne.App) {
-	recalibrateGyroUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/recalibrate/gyroscope"}
+func recalibrateGyro(profile RocketProfile) {
+	recalibrateGyroUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/recalibrate/gyroscope"}
 	post(recalibrateGyroUrl)
 }
 
-func recalibrateAccelerometer(App fyne.App) {
-	recalibrateAccelerometerUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/recalibrate/accelerometer"}
+func recalibrateAccelerometer(profile RocketProfile) {
+	recalibrateAccelerometerUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/recalibrate/accelerometer"}
 	post(recalibrateAccelerometerUrl)
 }
 
-func recalibrateBarometer(App fyne.App) {
-	recalibrateBarometerUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/recalibrate/barometer"}
+func recalibrateBarometer(profile RocketProfile) {
+	recalibrateBarometerUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/recalibrate/barometer"}
 	post(recalibrateBarometerUrl)
 }
 
-func resetMax(App fyne.App) {
-	resetMaxUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset/max"}
+func resetMax(profile RocketProfile) {
+	resetMaxUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset/max"}
 	post(resetMaxUrl)
 }
 
-func resetMin(App fyne.App) {
-	resetMinUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset/min"}
+func resetMin(profile RocketProfile) {
+	resetMinUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset/min"}
 	post(resetMinUrl)
 }
 
-func resetGyro(App fyne.App) {
-	resetGyroUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset/gyroscope"}
+func resetGyro(profile RocketProfile) {
+	resetGyroUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset/gyroscope"}
 	post(resetGyroUrl)
 }
 
-func resetAccelerometer(App fyne.App) {
-	resetAccelerometerUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset/accelerometer"}
+func resetAccelerometer(profile RocketProfile) {
+	resetAccelerometerUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset/accelerometer"}
 	post(resetAccelerometerUrl)
 }
 
-func resetBarometer(App fyne.App) {
-	resetBarometerUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/post/reset/barometer"}
+func resetBarometer(profile RocketProfile) {
+	resetBarometerUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/post/reset/barometer"}
 	post(resetBarometerUrl)
 }
 
-func getLog(App fyne.App) {
-	getLogUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/log"}
-	currentLog = parseCSVLog(get(getLogUrl))
+func getLog(profile RocketProfile) Log {
+	getLogUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/log"}
+	return downloadedLog(profile, get(getLogUrl))
 }
 
-func getLogById(App fyne.App, id int) {
-	getLogUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/log/" + string(rune(id))}
-	currentLog = parseCSVLog(get(getLogUrl))
+func getLogById(profile RocketProfile, id int) Log {
+	getLogUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/log/" + strconv.Itoa(id)}
+	return downloadedLog(profile, get(getLogUrl))
 }
 
-func getLogList(App fyne.App) LogList {
-	getLogListUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/logs"}
+// downloadedLog parses a log downloaded from the rocket of profile and tags its samples with the profile as source
+func downloadedLog(profile RocketProfile, csvString string) Log {
+	log, err := parseCSVLog(csvString)
+	if err != nil {
+		netLogger.Println("Skipped invalid log lines:", err)
+	}
+	for i := range log {
+		log[i].source = profile.Name
+	}
+	return log
+}
+
+func getLogList(profile RocketProfile) LogList {
+	getLogListUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/logs"}
 	logListString := get(getLogListUrl)
 	lines := strings.Split(logListString, "\n")
 	logList := make(LogList, len(lines))
@@ -212,13 +437,26 @@ func getLogList(App fyne.App) LogList {
 	return logList
 }
 
-func getLoggingStatus(App fyne.App) LoggingStatus {
-	getLoggingStatusUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/log/status"}
+func getLoggingStatus(profile RocketProfile) LoggingStatus {
+	getLoggingStatusUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/log/status"}
 	return toLoggingStatus(get(getLoggingStatusUrl))
 }
 
-func getVoltage(App fyne.App) float64 {
-	getVoltageUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/voltage"}
+func getBaseStationPressure(profile RocketProfile) (float64, error) {
+	getPressureUrl := url.URL{Scheme: "http", Host: profile.BaseStationHost, Path: "/get/pressure"}
+	pressureString, err := fetch(getPressureUrl)
+	if err != nil {
+		return 0, err
+	}
+	pressure, err := strconv.ParseFloat(strings.TrimSpace(pressureString), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing base station pressure: %w", err)
+	}
+	return pressure, nil
+}
+
+func getVoltage(profile RocketProfile) float64 {
+	getVoltageUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/voltage"}
 	voltageString := get(getVoltageUrl)
 	voltage, err := strconv.ParseFloat(voltageString, 64)
 	if err != nil {
@@ -228,13 +466,13 @@ func getVoltage(App fyne.App) float64 {
 	return voltage
 }
 
-func getStatus(App fyne.App) Status {
-	getStatusUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/status"}
+func getStatus(profile RocketProfile) Status {
+	getStatusUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/status"}
 	return toStatus(get(getStatusUrl))
 }
 
-func getHeight(App fyne.App) float64 {
-	getHeightUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/altitude"}
+func getHeight(profile RocketProfile) float64 {
+	getHeightUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/altitude"}
 	heightString := get(getHeightUrl)
 	height, err := strconv.ParseFloat(heightString, 64)
 	if err != nil {
@@ -244,8 +482,8 @@ func getHeight(App fyne.App) float64 {
 	return height
 }
 
-func getXAcceleration(App fyne.App) float64 {
-	getXAccelerationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/acceleration/x"}
+func getXAcceleration(profile RocketProfile) float64 {
+	getXAccelerationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/acceleration/x"}
 	xAccelerationString := get(getXAccelerationUrl)
 	xAcceleration, err := strconv.ParseFloat(xAccelerationString, 64)
 	if err != nil {
@@ -255,8 +493,8 @@ func getXAcceleration(App fyne.App) float64 {
 	return xAcceleration
 }
 
-func getYAcceleration(App fyne.App) float64 {
-	getYAccelerationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/acceleration/y"}
+func getYAcceleration(profile RocketProfile) float64 {
+	getYAccelerationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/acceleration/y"}
 	yAccelerationString := get(getYAccelerationUrl)
 	yAcceleration, err := strconv.ParseFloat(yAccelerationString, 64)
 	if err != nil {
@@ -266,8 +504,8 @@ func getYAcceleration(App fyne.App) float64 {
 	return yAcceleration
 }
 
-func getZAcceleration(App fyne.App) float64 {
-	getZAccelerationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/acceleration/z"}
+func getZAcceleration(profile RocketProfile) float64 {
+	getZAccelerationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/acceleration/z"}
 	zAccelerationString := get(getZAccelerationUrl)
 	zAcceleration, err := strconv.ParseFloat(zAccelerationString, 64)
 	if err != nil {
@@ -277,8 +515,8 @@ func getZAcceleration(App fyne.App) float64 {
 	return zAcceleration
 }
 
-func getXRotation(App fyne.App) float64 {
-	getXRotationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/rotation/x"}
+func getXRotation(profile RocketProfile) float64 {
+	getXRotationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/rotation/x"}
 	xRotationString := get(getXRotationUrl)
 	xRotation, err := strconv.ParseFloat(xRotationString, 64)
 	if err != nil {
@@ -288,8 +526,8 @@ func getXRotation(App fyne.App) float64 {
 	return xRotation
 }
 
-func getYRotation(App fyne.App) float64 {
-	getYRotationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/rotation/y"}
+func getYRotation(profile RocketProfile) float64 {
+	getYRotationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/rotation/y"}
 	yRotationString := get(getYRotationUrl)
 	yRotation, err := strconv.ParseFloat(yRotationString, 64)
 	if err != nil {
@@ -299,8 +537,8 @@ func getYRotation(App fyne.App) float64 {
 	return yRotation
 }
 
-func getZRotation(App fyne.App) float64 {
-	getZRotationUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/rotation/z"}
+func getZRotation(profile RocketProfile) float64 {
+	getZRotationUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/rotation/z"}
 	zRotationString := get(getZRotationUrl)
 	zRotation, err := strconv.ParseFloat(zRotationString, 64)
 	if err != nil {
@@ -310,8 +548,8 @@ func getZRotation(App fyne.App) float64 {
 	return zRotation
 }
 
-func getSpacialData(App fyne.App) SpacialData {
-	getSpacialDataUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/spacial-data"}
+func getSpacialData(profile RocketProfile) SpacialData {
+	getSpacialDataUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/spacial-data"}
 	spacialDataJsonString := get(getSpacialDataUrl)
 	var spacialData SpacialData
 	err := json.Unmarshal([]byte(spacialDataJsonString), &spacialData)
@@ -322,8 +560,8 @@ func getSpacialData(App fyne.App) SpacialData {
 	return spacialData
 }
 
-func getMaxAltitude(App fyne.App) float64 {
-	getMaxAltitudeUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/max/altitude"}
+func getMaxAltitude(profile RocketProfile) float64 {
+	getMaxAltitudeUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/max/altitude"}
 	maxAltitudeString := get(getMaxAltitudeUrl)
 	maxAltitude, err := strconv.ParseFloat(maxAltitudeString, 64)
 	if err != nil {
@@ -333,8 +571,8 @@ func getMaxAltitude(App fyne.App) float64 {
 	return maxAltitude
 }
 
-func getMinAltitude(App fyne.App) float64 {
-	getMinAltitudeUrl := url.URL{Scheme: "http", Host: App.Preferences().StringWithFallback("WaRaIP", "Not set"), Path: "/get/min/altitude"}
+func getMinAltitude(profile RocketProfile) float64 {
+	getMinAltitudeUrl := url.URL{Scheme: "http", Host: profile.RocketHost, Path: "/get/min/altitude"}
 	minAltitudeString := get(getMinAltitudeUrl)
 	minAltitude, err := strconv.ParseFloat(minAltitudeString, 64)
 	if err != nil {
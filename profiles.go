@@ -0,0 +1,406 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	profilesKey      = "RocketProfiles"
+	activeProfileKey = "ActiveRocketProfile"
+)
+
+type CalibrationOffsets struct {
+	Altitude      float64 `json:"altitude"`
+	Voltage       float64 `json:"voltage"`
+	XRotation     float64 `json:"xRotation"`
+	YRotation     float64 `json:"yRotation"`
+	ZRotation     float64 `json:"zRotation"`
+	XAcceleration float64 `json:"xAcceleration"`
+	YAcceleration float64 `json:"yAcceleration"`
+	ZAcceleration float64 `json:"zAcceleration"`
+}
+
+type RocketProfile struct {
+	Name            string             `json:"name"`
+	RocketHost      string             `json:"rocketHost"`
+	BaseStationHost string             `json:"baseStationHost"`
+	WebSocketPath   string             `json:"webSocketPath"`
+	Model           string             `json:"model"`
+	DryMass         float64            `json:"dryMass"`         // kg
+	WaterMass       float64            `json:"waterMass"`       // kg
+	Diameter        float64            `json:"diameter"`        // m
+	DragCoefficient float64            `json:"dragCoefficient"` // Dimensionless
+	Calibration     CalibrationOffsets `json:"calibration"`
+	Checklist       string             `json:"checklist"`
+}
+
+var (
+	profiles      []RocketProfile
+	activeProfile RocketProfile
+	profilesMu    sync.RWMutex
+)
+
+func (offsets CalibrationOffsets) apply(data Data) Data {
+	data.altitude += offsets.Altitude
+	data.maxAltitude += offsets.Altitude
+	data.voltage += offsets.Voltage
+	data.xRotation += offsets.XRotation
+	data.yRotation += offsets.YRotation
+	data.zRotation += offsets.ZRotation
+	data.xAcceleration += offsets.XAcceleration
+	data.yAcceleration += offsets.YAcceleration
+	data.zAcceleration += offsets.ZAcceleration
+	return data
+}
+
+func newRocketProfile(name string, settings Settings) RocketProfile {
+	return RocketProfile{
+		Name:            name,
+		RocketHost:      settings.RocketHost,
+		BaseStationHost: settings.BaseStationHost,
+		WebSocketPath:   settings.WebSocketPath,
+		Model:           ModelTwoStage,
+		DryMass:         0.2,
+		WaterMass:       0.5,
+		Diameter:        0.09,
+		DragCoefficient: 0.5,
+	}
+}
+
+func (profile RocketProfile) validate() error {
+	var errs []error
+	if strings.TrimSpace(profile.Name) == "" {
+		errs = append(errs, errors.New("name: must not be empty"))
+	}
+	if err := validateHost(profile.RocketHost); err != nil {
+		errs = append(errs, fmt.Errorf("rocket host: %w", err))
+	}
+	if err := validateHost(profile.BaseStationHost); err != nil {
+		errs = append(errs, fmt.Errorf("base station host: %w", err))
+	}
+	if err := validateWebSocketPath(profile.WebSocketPath); err != nil {
+		errs = append(errs, fmt.Errorf("WebSocket path: %w", err))
+	}
+	if profile.Model != ModelSingleStage && profile.Model != ModelTwoStage {
+		errs = append(errs, fmt.Errorf("model: must be %s or %s", ModelSingleStage, ModelTwoStage))
+	}
+	if profile.DryMass <= 0 || profile.WaterMass < 0 || profile.Diameter <= 0 || profile.DragCoefficient <= 0 {
+		errs = append(errs, errors.New("physical parameters: masses, diameter and drag coefficient must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+func initProfiles(App fyne.App) {
+	var loaded []RocketProfile
+	if stored := App.Preferences().String(profilesKey); stored != "" {
+		if err := json.Unmarshal([]byte(stored), &loaded); err != nil {
+			log.Println("Ignoring stored rocket profiles:", err)
+			loaded = nil
+		}
+	}
+	if len(loaded) == 0 {
+		loaded = []RocketProfile{newRocketProfile("Default", getSettings())}
+	}
+
+	active := loaded[0]
+	activeName := App.Preferences().String(activeProfileKey)
+	for _, profile := range loaded {
+		if profile.Name == activeName {
+			active = profile
+		}
+	}
+
+	profilesMu.Lock()
+	profiles = loaded
+	activeProfile = active
+	profilesMu.Unlock()
+
+	go func() {
+		settingsChannel := ps.Sub("settings")
+		for settings := range settingsChannel {
+			settings := settings.(Settings)
+			profile := getProfile()
+			if profile.RocketHost == settings.RocketHost && profile.BaseStationHost == settings.BaseStationHost && profile.WebSocketPath == settings.WebSocketPath {
+				continue
+			}
+			profile.RocketHost = settings.RocketHost
+			profile.BaseStationHost = settings.BaseStationHost
+			profile.WebSocketPath = settings.WebSocketPath
+			if err := saveProfile(App, profile.Name, profile); err != nil {
+				log.Println("Updating rocket profile:", err)
+			}
+		}
+	}()
+}
+
+func getProfile() RocketProfile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return activeProfile
+}
+
+func getProfiles() []RocketProfile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return append([]RocketProfile(nil), profiles...)
+}
+
+func findProfile(name string) (RocketProfile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return RocketProfile{}, false
+}
+
+func storeProfiles(App fyne.App) error {
+	content, err := json.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+	App.Preferences().SetString(profilesKey, string(content))
+	App.Preferences().SetString(activeProfileKey, activeProfile.Name)
+	return nil
+}
+
+// saveProfile replaces the profile with the given name, or adds it if there is none
+func saveProfile(App fyne.App, name string, profile RocketProfile) error {
+	if err := profile.validate(); err != nil {
+		return err
+	}
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	index := -1
+	for i, existing := range profiles {
+		if existing.Name == profile.Name && existing.Name != name {
+			return fmt.Errorf("a profile named %q already exists", profile.Name)
+		}
+		if existing.Name == name {
+			index = i
+		}
+	}
+	if index < 0 {
+		profiles = append(profiles, profile)
+	} else {
+		profiles[index] = profile
+	}
+	if activeProfile.Name == name {
+		activeProfile = profile
+	}
+	return storeProfiles(App)
+}
+
+func deleteProfile(App fyne.App, name string) error {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	if len(profiles) == 1 {
+		return errors.New("the last profile can not be deleted")
+	}
+	if activeProfile.Name == name {
+		return errors.New("switch to another profile before deleting this one")
+	}
+	for i, profile := range profiles {
+		if profile.Name == name {
+			profiles = append(profiles[:i:i], profiles[i+1:]...)
+			break
+		}
+	}
+	return storeProfiles(App)
+}
+
+// activateProfile makes the profile with the given name the active one, moves its connection info into the settings,
+// which reconnects the WebSocket, and publishes it on the "profile" topic
+func activateProfile(App fyne.App, name string) error {
+	profilesMu.Lock()
+	found := false
+	for _, profile := range profiles {
+		if profile.Name == name {
+			activeProfile = profile
+			found = true
+		}
+	}
+	if !found {
+		profilesMu.Unlock()
+		return fmt.Errorf("no profile named %q", name)
+	}
+	err := storeProfiles(App)
+	profile := activeProfile
+	profilesMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	settings := getSettings()
+	settings.RocketHost = profile.RocketHost
+	settings.BaseStationHost = profile.BaseStationHost
+	settings.WebSocketPath = profile.WebSocketPath
+	if err := applySettings(App, settings); err != nil {
+		return err
+	}
+	ps.Pub(profile, "profile")
+	return nil
+}
+
+func watchProfileModel(rocket *Rocket) {
+	rocket.SetModel(getProfile().Model)
+	go func() {
+		profileChannel := ps.Sub("profile")
+		for profile := range profileChannel {
+			rocket.SetModel(profile.(RocketProfile).Model)
+		}
+	}()
+}
+
+func profileSwitcher(App fyne.App, window fyne.Window) fyne.CanvasObject {
+	names := func() []string {
+		var names []string
+		for _, profile := range getProfiles() {
+			names = append(names, profile.Name)
+		}
+		return names
+	}
+
+	profileSelect := widget.NewSelect(names(), nil)
+	profileSelect.SetSelected(getProfile().Name)
+	profileSelect.OnChanged = func(name string) {
+		if name == getProfile().Name {
+			return
+		}
+		if err := activateProfile(App, name); err != nil {
+			dialog.ShowError(err, window)
+			profileSelect.SetSelected(getProfile().Name)
+		}
+	}
+	refresh := func() {
+		profileSelect.Options = names()
+		profileSelect.SetSelected(getProfile().Name)
+		profileSelect.Refresh()
+	}
+
+	editButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+		profile := getProfile()
+		showProfileDialog(App, window, "Edit profile", profile.Name, profile, refresh)
+	})
+	addButton := widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() {
+		showProfileDialog(App, window, "New profile", "", newRocketProfile("", getSettings()), refresh)
+	})
+	deleteButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		name := profileSelect.Selected
+		dialog.ShowConfirm("Delete profile", "Delete the profile "+name+"?", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := deleteProfile(App, name); err != nil {
+				dialog.ShowError(err, window)
+			}
+			refresh()
+		}, window)
+	})
+
+	go func() {
+		profileChannel := ps.Sub("profile")
+		for range profileChannel {
+			refresh()
+		}
+	}()
+
+	return container.NewBorder(nil, nil, widget.NewLabel("Rocket:"), container.NewHBox(editButton, addButton, deleteButton), profileSelect)
+}
+
+func showProfileDialog(App fyne.App, window fyne.Window, title, originalName string, profile RocketProfile, onSaved func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(profile.Name)
+	rocketHostEntry := widget.NewEntry()
+	rocketHostEntry.SetText(profile.RocketHost)
+	rocketHostEntry.Validator = validateHost
+	baseStationHostEntry := widget.NewEntry()
+	baseStationHostEntry.SetText(profile.BaseStationHost)
+	baseStationHostEntry.Validator = validateHost
+	webSocketPathEntry := widget.NewEntry()
+	webSocketPathEntry.SetText(profile.WebSocketPath)
+	webSocketPathEntry.Validator = validateWebSocketPath
+	modelSelect := widget.NewSelect(rocketModels, nil)
+	modelSelect.SetSelected(profile.Model)
+	checklistEntry := widget.NewEntry()
+	checklistEntry.SetText(profile.Checklist)
+
+	numbers := []struct {
+		label string
+		value *float64
+	}{
+		{"Dry mass (kg)", &profile.DryMass},
+		{"Water mass (kg)", &profile.WaterMass},
+		{"Diameter (m)", &profile.Diameter},
+		{"Drag coefficient", &profile.DragCoefficient},
+		{"Altitude offset (m)", &profile.Calibration.Altitude},
+		{"Voltage offset (V)", &profile.Calibration.Voltage},
+		{"X rotation offset (°)", &profile.Calibration.XRotation},
+		{"Y rotation offset (°)", &profile.Calibration.YRotation},
+		{"Z rotation offset (°)", &profile.Calibration.ZRotation},
+		{"X acceleration offset (m/s²)", &profile.Calibration.XAcceleration},
+		{"Y acceleration offset (m/s²)", &profile.Calibration.YAcceleration},
+		{"Z acceleration offset (m/s²)", &profile.Calibration.ZAcceleration},
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Rocket host", rocketHostEntry),
+		widget.NewFormItem("Base station host", baseStationHostEntry),
+		widget.NewFormItem("WebSocket path", webSocketPathEntry),
+		widget.NewFormItem("3D model", modelSelect),
+		widget.NewFormItem("Checklist", checklistEntry),
+	}
+	numberEntries := make([]*widget.Entry, len(numbers))
+	for i, number := range numbers {
+		numberEntries[i] = widget.NewEntry()
+		numberEntries[i].SetText(strconv.FormatFloat(*number.value, 'f', -1, 64))
+		items = append(items, widget.NewFormItem(number.label, numberEntries[i]))
+	}
+
+	form := dialog.NewForm(title, "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		profile.Name = strings.TrimSpace(nameEntry.Text)
+		profile.RocketHost = strings.TrimSpace(rocketHostEntry.Text)
+		profile.BaseStationHost = strings.TrimSpace(baseStationHostEntry.Text)
+		profile.WebSocketPath = strings.TrimSpace(webSocketPathEntry.Text)
+		profile.Model = modelSelect.Selected
+		profile.Checklist = strings.TrimSpace(checklistEntry.Text)
+		for i, number := range numbers {
+			value, err := strconv.ParseFloat(strings.TrimSpace(numberEntries[i].Text), 64)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("%s: must be a number", number.label), window)
+				return
+			}
+			*number.value = value
+		}
+
+		if err := saveProfile(App, originalName, profile); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if originalName == "" || originalName == getProfile().Name || profile.Name == getProfile().Name {
+			if err := activateProfile(App, profile.Name); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}
+		onSaved()
+	}, window)
+	form.Resize(fyne.NewSize(500, 600))
+	form.Show()
+}
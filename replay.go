@@ -0,0 +1,332 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const replayProgressFPS = 10
+
+// replaySource is the source of replayed samples, so they show up as their own rocket instead of mixing with the live
+// telemetry of the rocket they were recorded from
+const replaySource = "Replay"
+
+var replaySpeeds = []string{"0.25x", "0.5x", "1x", "2x", "5x", "10x"}
+
+// ReplaySeek is published on "replaySeek" whenever the replay jumps, with the samples that lie before the new position
+type ReplaySeek struct {
+	Played Log
+}
+
+// DownloadedLog is published on "log" when the log of a rocket has been downloaded
+type DownloadedLog struct {
+	Source string
+	Log    Log
+}
+
+// ReplayEngine publishes a recorded log on "newData" with the original timing, scaled by the replay speed, so the views
+// show the flight as it happened. The samples are tagged with replaySource
+type ReplayEngine struct {
+	log          Log
+	offsets      []time.Duration // Time of every sample since the start of the recording
+	position     int             // Index of the next sample to publish
+	speed        float64
+	playing      bool
+	anchorTime   time.Time
+	anchorOffset time.Duration
+	wake         chan struct{}
+	mu           sync.Mutex
+}
+
+var replay = newReplayEngine()
+
+func newReplayEngine() *ReplayEngine {
+	engine := &ReplayEngine{speed: 1, wake: make(chan struct{}, 1)}
+	go engine.run()
+	return engine
+}
+
+// logOffsets returns the time of every sample since the first one. The timestamps of the rocket are used if every
+// sample has one, otherwise the samples are spaced sampleInterval apart
+func logOffsets(log Log, sampleInterval time.Duration) []time.Duration {
+	offsets := make([]time.Duration, len(log))
+	for _, data := range log {
+		if !data.hasRocketTime {
+			for i := range offsets {
+				offsets[i] = time.Duration(i) * sampleInterval
+			}
+			return offsets
+		}
+	}
+	for i, data := range log {
+		offsets[i] = data.rocketTime - log[0].rocketTime
+		// A timestamp that went back, e.g. because the rocket restarted, must not move the sample before the previous one
+		if i > 0 && offsets[i] < offsets[i-1] {
+			offsets[i] = offsets[i-1]
+		}
+	}
+	return offsets
+}
+
+// Load replaces the replayed log. Loading an empty log closes the replay and the charts go back to live telemetry
+func (engine *ReplayEngine) Load(log Log, offsets []time.Duration) {
+	tagged := make(Log, len(log))
+	for i, data := range log {
+		data.source = replaySource
+		data.replayTime = offsets[i]
+		tagged[i] = data
+	}
+	engine.mu.Lock()
+	engine.log = tagged
+	engine.offsets = offsets
+	engine.position = 0
+	engine.playing = false
+	engine.mu.Unlock()
+	engine.notify()
+	ps.Pub(ReplaySeek{}, "replaySeek")
+}
+
+// Loaded returns the log that is being replayed
+func (engine *ReplayEngine) Loaded() Log {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	return engine.log
+}
+
+func (engine *ReplayEngine) Play() {
+	engine.mu.Lock()
+	restart := engine.position >= len(engine.log)
+	if restart {
+		engine.position = 0
+	}
+	engine.playing = len(engine.log) > 0
+	engine.anchor()
+	engine.mu.Unlock()
+	engine.notify()
+	if restart {
+		ps.Pub(ReplaySeek{}, "replaySeek")
+	}
+}
+
+func (engine *ReplayEngine) Pause() {
+	engine.mu.Lock()
+	engine.playing = false
+	engine.mu.Unlock()
+	engine.notify()
+}
+
+func (engine *ReplayEngine) Playing() bool {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	return engine.playing
+}
+
+func (engine *ReplayEngine) SetSpeed(speed float64) {
+	engine.mu.Lock()
+	engine.speed = speed
+	engine.anchor()
+	engine.mu.Unlock()
+	engine.notify()
+}
+
+// Seek jumps to offset without publishing the samples in between. The last sample before offset is published so the
+// views show the state at that time
+func (engine *ReplayEngine) Seek(offset time.Duration) {
+	engine.mu.Lock()
+	position := sort.Search(len(engine.offsets), func(i int) bool { return engine.offsets[i] > offset })
+	engine.mu.Unlock()
+	engine.seekIndex(position)
+}
+
+// Step pauses the replay and moves it by the given number of samples. Stepping forward publishes every sample it passes
+func (engine *ReplayEngine) Step(samples int) {
+	engine.mu.Lock()
+	engine.playing = false
+	target := min(max(engine.position+samples, 0), len(engine.log))
+	start := engine.position
+	engine.mu.Unlock()
+	engine.notify()
+
+	if target < start {
+		engine.seekIndex(target)
+		return
+	}
+	engine.mu.Lock()
+	stepped := append(Log(nil), engine.log[start:target]...)
+	engine.position = target
+	engine.mu.Unlock()
+	for _, data := range stepped {
+		ps.Pub(data, "newData")
+	}
+}
+
+// Progress returns the time of the last published sample and the length of the recording
+func (engine *ReplayEngine) Progress() (time.Duration, time.Duration) {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	if len(engine.offsets) == 0 {
+		return 0, 0
+	}
+	return engine.currentOffset(), engine.offsets[len(engine.offsets)-1]
+}
+
+func (engine *ReplayEngine) seekIndex(position int) {
+	engine.mu.Lock()
+	engine.position = position
+	engine.anchor()
+	played := append(Log(nil), engine.log[:position]...)
+	engine.mu.Unlock()
+	engine.notify()
+
+	ps.Pub(ReplaySeek{Played: played}, "replaySeek")
+	if len(played) > 0 {
+		ps.Pub(played[len(played)-1], "newData")
+	}
+}
+
+func (engine *ReplayEngine) currentOffset() time.Duration {
+	if engine.position == 0 {
+		if len(engine.offsets) == 0 {
+			return 0
+		}
+		return engine.offsets[0]
+	}
+	return engine.offsets[engine.position-1]
+}
+
+// anchor pins the current recording time to the current wall time. It has to be called with mu locked
+func (engine *ReplayEngine) anchor() {
+	engine.anchorTime = time.Now()
+	engine.anchorOffset = engine.currentOffset()
+}
+
+func (engine *ReplayEngine) notify() {
+	select {
+	case engine.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (engine *ReplayEngine) run() {
+	for {
+		engine.mu.Lock()
+		if !engine.playing || engine.position >= len(engine.log) {
+			engine.playing = false
+			engine.mu.Unlock()
+			<-engine.wake
+			continue
+		}
+		position := engine.position
+		due := engine.anchorTime.Add(time.Duration(float64(engine.offsets[position]-engine.anchorOffset) / engine.speed))
+		engine.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-timer.C:
+		case <-engine.wake:
+			timer.Stop()
+			continue
+		}
+
+		engine.mu.Lock()
+		if !engine.playing || engine.position != position {
+			engine.mu.Unlock()
+			continue
+		}
+		data := engine.log[position]
+		engine.position++
+		engine.mu.Unlock()
+		ps.Pub(data, "newData")
+	}
+}
+
+func formatReplayTime(duration time.Duration) string {
+	return fmt.Sprintf("%d:%04.1f", int(duration.Minutes()), duration.Seconds()-float64(int(duration.Minutes())*60))
+}
+
+func replayControls(window fyne.Window) fyne.CanvasObject {
+	timeLabel := widget.NewLabel(formatReplayTime(0) + " / " + formatReplayTime(0))
+	timeline := widget.NewSlider(0, 1)
+	timeline.Step = 0.001
+	timeline.OnChangeEnded = func(value float64) {
+		_, total := replay.Progress()
+		go replay.Seek(time.Duration(value * float64(total)))
+	}
+
+	var playButton *widget.Button
+	playButton = widget.NewButtonWithIcon("", theme.MediaPlayIcon(), func() {
+		if replay.Playing() {
+			go replay.Pause()
+		} else {
+			go replay.Play()
+		}
+	})
+	stepBackButton := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() { go replay.Step(-1) })
+	stepForwardButton := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() { go replay.Step(1) })
+
+	speedSelect := widget.NewSelect(replaySpeeds, func(speed string) {
+		parsed, err := strconv.ParseFloat(strings.TrimSuffix(speed, "x"), 64)
+		if err == nil {
+			replay.SetSpeed(parsed)
+		}
+	})
+	speedSelect.SetSelected("1x")
+
+	var downloaded Log
+	var downloadedMu sync.Mutex
+	replayLogButton := widget.NewButton("Replay log", func() {
+		downloadedMu.Lock()
+		log := downloaded
+		downloadedMu.Unlock()
+		if len(log) == 0 {
+			dialog.ShowError(errors.New("get the log from a rocket first"), window)
+			return
+		}
+		go replay.Load(log, logOffsets(log, defaultReplaySampleInterval))
+	})
+
+	closeButton := widget.NewButtonWithIcon("", theme.MediaStopIcon(), func() { go replay.Load(nil, nil) })
+
+	go func() {
+		logChannel := ps.Sub("log")
+		for downloadedLog := range logChannel {
+			downloadedMu.Lock()
+			downloaded = downloadedLog.(DownloadedLog).Log
+			downloadedMu.Unlock()
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(time.Second / replayProgressFPS)
+		for range ticker.C {
+			current, total := replay.Progress()
+			timeLabel.SetText(formatReplayTime(current) + " / " + formatReplayTime(total))
+			if total > 0 {
+				timeline.SetValue(float64(current) / float64(total))
+			} else {
+				timeline.SetValue(0)
+			}
+			if replay.Playing() {
+				playButton.SetIcon(theme.MediaPauseIcon())
+			} else {
+				playButton.SetIcon(theme.MediaPlayIcon())
+			}
+		}
+	}()
+
+	return container.NewBorder(nil, nil,
+		container.NewHBox(replayLogButton, stepBackButton, playButton, stepForwardButton, closeButton),
+		container.NewHBox(speedSelect, timeLabel),
+		timeline,
+	)
+}
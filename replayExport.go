@@ -0,0 +1,216 @@
+package main
+
+import (
+	"FlightControl/ThreeDView"
+	"FlightControl/ThreeDView/camera"
+	"FlightControl/ThreeDView/types"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	xdraw "golang.org/x/image/draw"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultReplaySampleInterval = 10 * time.Millisecond
+	maxGIFWidth                 = 480 // GIF frames are scaled down to this width to bound the memory they need
+	maxGIFFrames                = 300 // Longer replays skip frames in the GIF
+)
+
+// ReplayExportOptions configures how a recorded log is rendered to images
+type ReplayExportOptions struct {
+	Directory      string        // Directory the frames and the GIF are written to
+	FrameRate      float64       // Frames per second of flight time
+	SampleInterval time.Duration // Time between two samples of logs without timestamps
+	Width          int
+	Height         int
+	CameraMode     string // One of cameraModes
+	GIF            bool   // Whether an animated GIF is written in addition to the PNG frames
+}
+
+// exportReplay steps a recorded log through an offscreen 3D scene and writes one PNG per frame.
+// progress is called with the fraction of frames that are done
+func exportReplay(log Log, options ReplayExportOptions, progress func(float64)) error {
+	if len(log) == 0 {
+		return errors.New("the log is empty")
+	}
+	if options.FrameRate <= 0 {
+		return errors.New("the frame rate has to be positive")
+	}
+	if options.Width <= 0 || options.Height <= 0 {
+		return errors.New("the resolution has to be positive")
+	}
+	if options.SampleInterval <= 0 {
+		options.SampleInterval = defaultReplaySampleInterval
+	}
+	if err := os.MkdirAll(options.Directory, 0755); err != nil {
+		return err
+	}
+
+	threeDEnv := ThreeDView.NewOffscreenThreeDWidget(types.Pixel(options.Width), types.Pixel(options.Height))
+	threeDEnv.SetSkyGradient(skyHorizonColor, skyZenithColor)
+	NewLaunchSite(5000, 5, []float64{10, 20}, threeDEnv)
+	rocket := NewRocket(getProfile().Model, types.Point3D{}, types.Rotation3D{}, threeDEnv)
+	rocket.manualAnimation = true
+	defer rocket.Stop()
+	trail := NewFlightTrail(types.Point3D{}, threeDEnv)
+	threeDEnv.AddHUDElement(ThreeDView.HUDTopLeft, func() string {
+		return fmt.Sprintf("Altitude: %.1f m\nSpeed: %.1f m/s\nStatus: %s", rocket.GetData().altitude, rocket.GetData().speed(), rocket.GetData().status)
+	})
+
+	controller, ok := newCameraControllers(rocket)[options.CameraMode]
+	if !ok {
+		return fmt.Errorf("unknown camera mode %q", options.CameraMode)
+	}
+	envCamera := camera.NewCamera(types.Point3D{Y: 500, Z: 200}, types.Rotation3D{})
+	envCamera.SetController(controller)
+	threeDEnv.SetCamera(&envCamera)
+
+	offsets := logOffsets(log, options.SampleInterval)
+	frameCount := int(offsets[len(offsets)-1].Seconds()*options.FrameRate) + 1
+	frameInterval := time.Duration(float64(time.Second) / options.FrameRate)
+	var gifImage gif.GIF
+	gifStride := (frameCount + maxGIFFrames - 1) / maxGIFFrames
+	gifDelay := int(math.Round(100 * float64(gifStride) / options.FrameRate))
+
+	// The scene is animated with the flight time instead of the wall clock, so the frames don't depend on how fast
+	// they are rendered
+	start := time.Unix(0, 0)
+	nextSample := 0
+	for frame := 0; frame < frameCount; frame++ {
+		frameTime := time.Duration(frame) * frameInterval
+		for ; nextSample < len(log) && offsets[nextSample] <= frameTime; nextSample++ {
+			rocket.ApplyData(log[nextSample])
+			trail.AddSample(log[nextSample])
+		}
+		if frame > 0 {
+			rocket.animate(frameInterval)
+		}
+		if timed, ok := envCamera.Controller.(camera.TimedController); ok {
+			timed.UpdateAt(start.Add(frameTime))
+		} else {
+			envCamera.Controller.Update()
+		}
+		threeDEnv.Tick()
+		img := threeDEnv.RenderFrame()
+
+		if err := writePNG(filepath.Join(options.Directory, fmt.Sprintf("frame_%05d.png", frame)), img); err != nil {
+			return err
+		}
+		if options.GIF && frame%gifStride == 0 {
+			gifImage.Image = append(gifImage.Image, gifFrame(img))
+			gifImage.Delay = append(gifImage.Delay, gifDelay)
+		}
+		if progress != nil {
+			progress(float64(frame+1) / float64(frameCount))
+		}
+	}
+
+	if !options.GIF {
+		return nil
+	}
+	file, err := os.Create(filepath.Join(options.Directory, "replay.gif"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gif.EncodeAll(file, &gifImage)
+}
+
+func showReplayExportDialog(window fyne.Window) {
+	log := replay.Loaded()
+	if len(log) == 0 {
+		dialog.ShowInformation("Export replay", "Load a replay in the Control tab first", window)
+		return
+	}
+
+	frameRateEntry := widget.NewEntry()
+	frameRateEntry.SetText("25")
+	sampleIntervalEntry := widget.NewEntry()
+	sampleIntervalEntry.SetText(strconv.Itoa(int(defaultReplaySampleInterval / time.Millisecond)))
+	resolutionSelect := widget.NewSelect([]string{"640x480", "1280x720", "1920x1080"}, nil)
+	resolutionSelect.SetSelectedIndex(0)
+	cameraSelect := widget.NewSelect(cameraModes[:3], nil)
+	cameraSelect.SetSelectedIndex(0)
+	gifCheck := widget.NewCheck("Also write an animated GIF", nil)
+
+	dialog.ShowForm("Export replay", "Choose folder", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Frame rate", frameRateEntry),
+		widget.NewFormItem("Sample interval without timestamps (ms)", sampleIntervalEntry),
+		widget.NewFormItem("Resolution", resolutionSelect),
+		widget.NewFormItem("Camera", cameraSelect),
+		widget.NewFormItem("", gifCheck),
+	}, func(ok bool) {
+		if !ok {
+			return
+		}
+		options := ReplayExportOptions{CameraMode: cameraSelect.Selected, GIF: gifCheck.Checked}
+		var err error
+		if options.FrameRate, err = strconv.ParseFloat(frameRateEntry.Text, 64); err != nil {
+			dialog.ShowError(fmt.Errorf("invalid frame rate: %w", err), window)
+			return
+		}
+		sampleInterval, err := strconv.Atoi(sampleIntervalEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid sample interval: %w", err), window)
+			return
+		}
+		options.SampleInterval = time.Duration(sampleInterval) * time.Millisecond
+		fmt.Sscanf(resolutionSelect.Selected, "%dx%d", &options.Width, &options.Height)
+
+		dialog.ShowFolderOpen(func(folder fyne.ListableURI, err error) {
+			if err != nil || folder == nil {
+				return
+			}
+			options.Directory = folder.Path()
+			progressBar := widget.NewProgressBar()
+			progressDialog := dialog.NewCustomWithoutButtons("Exporting replay", progressBar, window)
+			progressDialog.Show()
+			go func() {
+				err := exportReplay(log, options, progressBar.SetValue)
+				progressDialog.Hide()
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+				dialog.ShowInformation("Export replay", "The replay was written to "+options.Directory, window)
+			}()
+		}, window)
+	}, window)
+}
+
+// gifFrame scales a frame down to at most maxGIFWidth and reduces it to the GIF palette
+func gifFrame(img image.Image) *image.Paletted {
+	bounds := img.Bounds()
+	if bounds.Dx() > maxGIFWidth {
+		scaled := image.NewRGBA(image.Rect(0, 0, maxGIFWidth, bounds.Dy()*maxGIFWidth/bounds.Dx()))
+		xdraw.ApproxBiLinear.Scale(scaled, scaled.Bounds(), img, bounds, xdraw.Src, nil)
+		img = scaled
+	}
+	paletted := image.NewPaletted(img.Bounds(), palette.Plan9)
+	draw.FloydSteinberg.Draw(paletted, img.Bounds(), img, image.Point{})
+	return paletted
+}
+
+func writePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -4,36 +4,68 @@ import (
 	"FlightControl/ThreeDView/object"
 	"FlightControl/ThreeDView/types"
 	"image/color"
+	"sync"
 	"time"
 )
 
 const (
-	tipHeight   = types.Unit(30)
-	stageHeight = types.Unit(60)
-	radius      = types.Unit(10)
+	tipHeight     = types.Unit(30)
+	stageHeight   = types.Unit(60)
+	radius        = types.Unit(10)
+	altitudeScale = types.Unit(100) // Units in the 3D scene per meter of altitude
 )
 
+const (
+	stageAnimationStep = 10 * time.Millisecond
+	stageFallSpeed     = types.Unit(200)    // Units per second a separated stage falls
+	stageTumbleSpeed   = types.Degrees(100) // Degrees per second a separated stage tumbles around each axis
+)
+
+const (
+	ModelSingleStage = "single-stage"
+	ModelTwoStage    = "two-stage"
+)
+
+var rocketModels = []string{ModelSingleStage, ModelTwoStage}
+
 type Rocket struct {
-	objects     []*object.Object
-	rotation    types.Rotation3D
-	position    types.Point3D
-	seperated   bool
-	DataChannel chan Data
+	body            *object.Object   // The root node of the rocket. All parts are children of it
+	pad             types.Point3D    // The launch site the horizontal position of the samples is relative to
+	objects         []*object.Object // The parts of the rocket: tip, stage 1 and stage 2
+	data            Data             // The latest telemetry sample
+	hasData         bool             // Whether the rocket received any telemetry
+	seperated       bool
+	stageFalling    bool // Whether the separated stage is still falling
+	manualAnimation bool // Whether the fall of a separated stage is advanced with animate instead of in real time
+	model           string
+	widget          object.ThreeDWidgetInterface
+	mu              sync.Mutex // Guards the telemetry and the stage state, which the data, UI and render goroutines share
 }
 
 func NewTwoStageRocket(position types.Point3D, rotation types.Rotation3D, w object.ThreeDWidgetInterface) *Rocket {
+	return NewRocket(ModelTwoStage, position, rotation, w)
+}
+
+func NewSingleStageRocket(position types.Point3D, rotation types.Rotation3D, w object.ThreeDWidgetInterface) *Rocket {
+	return NewRocket(ModelSingleStage, position, rotation, w)
+}
+
+func NewRocket(model string, position types.Point3D, rotation types.Rotation3D, w object.ThreeDWidgetInterface) *Rocket {
+	pad := position
+	position.Z += 180
 	rocket := Rocket{
-		objects:     make([]*object.Object, 3),
-		rotation:    rotation,
-		position:    position,
-		seperated:   false,
-		DataChannel: make(chan Data),
+		body:      object.NewEmpty(w, position),
+		pad:       pad,
+		objects:   make([]*object.Object, 3),
+		seperated: false,
+		model:     ModelTwoStage,
+		widget:    w,
 	}
-	rocket.position.Z += 180
+	rocket.body.Rotation = rotation
 
 	tip := object.NewCone(
-		rocket.position,
-		rocket.rotation,
+		types.Point3D{},
+		types.Rotation3D{},
 		color.RGBA{R: 200, G: 200, B: 200, A: 255},
 		w,
 		tipHeight,
@@ -41,100 +73,199 @@ func NewTwoStageRocket(position types.Point3D, rotation types.Rotation3D, w obje
 	)
 
 	stage1 := object.NewCylinder(
-		types.Point3D{X: rocket.position.X, Y: rocket.position.Y, Z: rocket.position.Z - tipHeight*1.5},
-		rocket.rotation,
+		types.Point3D{Z: -tipHeight * 1.5},
+		types.Rotation3D{},
 		color.RGBA{R: 150, G: 150, B: 150, A: 255},
 		w,
-		types.Unit(60),
-		types.Unit(10),
+		stageHeight,
+		radius,
 	)
 
 	stage2 := object.NewCylinder(
-		types.Point3D{X: rocket.position.X, Y: rocket.position.Y, Z: rocket.position.Z - tipHeight*1.5 - stageHeight},
-		rocket.rotation,
+		rocket.stage2Position(),
+		types.Rotation3D{},
 		color.RGBA{R: 100, G: 100, B: 100, A: 255},
 		w,
-		types.Unit(60),
-		types.Unit(10),
+		stageHeight,
+		radius,
 	)
 
 	rocket.objects[0] = tip
 	rocket.objects[1] = stage1
 	rocket.objects[2] = stage2
-
-	go rocket.listenForData()
+	for _, part := range rocket.objects {
+		rocket.body.AddChild(part)
+	}
+	rocket.SetModel(model)
 
 	return &rocket
 }
 
 func (rocket *Rocket) GetSensorPosition() types.Point3D {
-	return rocket.position
+	return rocket.body.GetWorldPosition()
 }
 
 func (rocket *Rocket) GetPosition() types.Point3D {
-	sensorPosition := rocket.position
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
 	if rocket.seperated {
-		sensorPosition.Z -= (stageHeight) / 2
-	} else {
-		sensorPosition.Z -= (stageHeight * 2) / 2
+		return rocket.body.ToWorld(types.Point3D{Z: -stageHeight / 2})
 	}
-	sensorPosition.Rotate(rocket.position, rocket.rotation)
-	return sensorPosition
+	return rocket.body.ToWorld(types.Point3D{Z: -stageHeight})
 }
 
 func (rocket *Rocket) GetRotation() types.Rotation3D {
-	return rocket.rotation
+	return rocket.body.GetWorldRotation()
+}
+
+func (rocket *Rocket) GetVelocity() types.Point3D {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	velocity := types.Point3D{X: types.Unit(rocket.data.xVelocity), Y: types.Unit(rocket.data.yVelocity), Z: types.Unit(rocket.data.zVelocity)}
+	velocity.Scale(altitudeScale)
+	return velocity
+}
+
+func (rocket *Rocket) GetData() Data {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	return rocket.data
+}
+
+func (rocket *Rocket) HasData() bool {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	return rocket.hasData
+}
+
+func (rocket *Rocket) Contains(obj *object.Object) bool {
+	return obj == rocket.body || obj.IsDescendantOf(rocket.body)
 }
 
 func (rocket *Rocket) Move(position types.Point3D) {
-	rocket.position.Add(position)
-	for _, obj := range rocket.objects {
-		obj.Position.Add(position)
-	}
+	rocket.body.Position.Add(position)
 }
 
 func (rocket *Rocket) SetPosition(position types.Point3D) {
-	rocket.position = position
-	rocket.objects[0].Position = rocket.position
-	rocket.objects[1].Position = types.Point3D{X: rocket.position.X, Y: rocket.position.Y, Z: rocket.position.Z - tipHeight*1.5}
-	rocket.objects[2].Position = types.Point3D{X: rocket.position.X, Y: rocket.position.Y, Z: rocket.position.Z - tipHeight*1.5 - stageHeight}
+	rocket.body.Position = position
 }
 
 func (rocket *Rocket) SetRotation(rotation types.Rotation3D) {
-	rocket.rotation = rotation
-	for _, obj := range rocket.objects {
-		obj.Rotation = rotation
-		obj.Position.Rotate(rocket.position, rotation)
+	rocket.body.Rotation = rotation
+}
+
+func (rocket *Rocket) stage2Position() types.Point3D {
+	return types.Point3D{Z: -tipHeight*1.5 - stageHeight}
+}
+
+// SetModel switches between the single and two stage model. The second stage is attached again if it was separated
+func (rocket *Rocket) SetModel(model string) {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	rocket.setModel(model)
+}
+
+// ReattachStage attaches a separated stage again, e.g. when a replay is rewound
+func (rocket *Rocket) ReattachStage() {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	rocket.setModel(rocket.model)
+}
+
+func (rocket *Rocket) setModel(model string) {
+	stage2 := rocket.objects[2]
+	remover, canRemove := rocket.widget.(interface{ RemoveObject(*object.Object) })
+	if model == ModelSingleStage {
+		if rocket.model == ModelTwoStage && canRemove {
+			stage2.SetParent(nil)
+			remover.RemoveObject(stage2)
+		}
+	} else {
+		model = ModelTwoStage
+		if rocket.model == ModelSingleStage {
+			rocket.widget.AddObject(stage2)
+		}
+		if rocket.model == ModelSingleStage || rocket.seperated {
+			stage2.Position = rocket.stage2Position()
+			stage2.Rotation = types.Rotation3D{}
+			rocket.body.AddChild(stage2)
+		}
 	}
+	rocket.model = model
+	rocket.seperated = false
+	rocket.stageFalling = false
 }
 
+// SeparateStage detaches the second stage and lets it fall to the ground
 func (rocket *Rocket) SeparateStage() {
-	if rocket.seperated {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	rocket.separateStage()
+}
+
+func (rocket *Rocket) separateStage() {
+	if rocket.seperated || rocket.model != ModelTwoStage {
 		return
 	}
-	seperatedStage := rocket.objects[2]
-	rocket.objects[2] = object.NewEmpty(seperatedStage.Widget, seperatedStage.Position)
+	rocket.objects[2].SetParent(nil)
 	rocket.seperated = true
+	rocket.stageFalling = true
+	if rocket.manualAnimation {
+		return
+	}
 	go func() {
-		for {
-			if seperatedStage.Position.Z <= 0 {
-				break
-			}
-			seperatedStage.Position.Z -= 2
-			seperatedStage.Rotation.Add(types.Rotation3D{Roll: 1, Pitch: 1, Yaw: 1})
-			time.Sleep(time.Millisecond * 10)
+		for rocket.animate(stageAnimationStep) {
+			time.Sleep(stageAnimationStep)
 		}
-		seperatedStage.Rotation.Roll = 90
-		seperatedStage.Position.Z = 15
 	}()
 }
 
-func (rocket *Rocket) listenForData() {
-	for data := range rocket.DataChannel {
-		rocket.SetPosition(types.Point3D{X: rocket.position.X, Y: rocket.position.Y, Z: types.Unit(data.altitude) * 100})
-		rocket.SetRotation(types.Rotation3D{Roll: types.Degrees(data.xRotation), Pitch: types.Degrees(data.yRotation), Yaw: types.Degrees(data.zRotation)})
-		if data.status.toIndex() > Status(StatusBoostedAscent).toIndex() && data.status != StatusError {
-			rocket.SeparateStage()
-		}
+// animate advances the fall of the separated stage by elapsed. It returns false once the stage is on the ground
+func (rocket *Rocket) animate(elapsed time.Duration) bool {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	if !rocket.stageFalling {
+		return false
+	}
+	stage := rocket.objects[2]
+	if stage.Position.Z <= 0 {
+		stage.Rotation = types.Rotation3D{Roll: 90}
+		stage.Position.Z = 15
+		rocket.stageFalling = false
+		return false
+	}
+	seconds := elapsed.Seconds()
+	tumble := stageTumbleSpeed * types.Degrees(seconds)
+	stage.Position.Z -= stageFallSpeed * types.Unit(seconds)
+	stage.Rotation.Compose(types.Rotation3D{Roll: tumble, Pitch: tumble, Yaw: tumble})
+	return true
+}
+
+// Stop ends the fall of a separated stage
+func (rocket *Rocket) Stop() {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	rocket.stageFalling = false
+}
+
+// ApplyData moves and turns the rocket to a telemetry sample and separates the stage once the sample is past the
+// boosted ascent. It can be called from any goroutine
+func (rocket *Rocket) ApplyData(data Data) {
+	rocket.mu.Lock()
+	defer rocket.mu.Unlock()
+	rocket.applyData(data)
+}
+
+func (rocket *Rocket) applyData(data Data) {
+	rocket.SetPosition(types.Point3D{
+		X: rocket.pad.X + types.Unit(data.xPosition)*altitudeScale,
+		Y: rocket.pad.Y + types.Unit(data.yPosition)*altitudeScale,
+		Z: types.Unit(data.altitude) * altitudeScale,
+	})
+	rocket.SetRotation(types.Rotation3D{Roll: types.Degrees(data.xRotation), Pitch: types.Degrees(data.yRotation), Yaw: types.Degrees(data.zRotation)})
+	rocket.data = data
+	rocket.hasData = true
+	if data.status.toIndex() > Status(StatusBoostedAscent).toIndex() && data.status != StatusError {
+		rocket.separateStage()
 	}
 }
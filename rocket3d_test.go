@@ -0,0 +1,42 @@
+package main
+
+import (
+	"FlightControl/ThreeDView"
+	"FlightControl/ThreeDView/types"
+	"sync"
+	"testing"
+)
+
+func TestRocketApplyData(t *testing.T) {
+	w := ThreeDView.NewOffscreenThreeDWidget(100, 100)
+	rocket := NewRocket(ModelTwoStage, types.Point3D{}, types.Rotation3D{}, w)
+	rocket.manualAnimation = true
+
+	// Telemetry of several connections arrives concurrently while the scene reads the rocket
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for altitude := 0; altitude < 50; altitude++ {
+				rocket.ApplyData(Data{altitude: float64(altitude), status: StatusPoweredAscent})
+				rocket.GetPosition()
+			}
+		}()
+	}
+	wg.Wait()
+	if data := rocket.GetData(); data.altitude != 49 || !rocket.HasData() {
+		t.Errorf("altitude %v, expected the last sample", data.altitude)
+	}
+
+	// Samples after the rocket was stopped are still applied instead of blocking or panicking
+	rocket.Stop()
+	rocket.ApplyData(Data{altitude: 60, status: StatusDescent})
+	if !rocket.seperated {
+		t.Error("the stage wasn't separated after the boosted ascent")
+	}
+	rocket.ReattachStage()
+	if rocket.seperated || rocket.GetData().altitude != 60 {
+		t.Errorf("separated %v and altitude %v after reattaching", rocket.seperated, rocket.GetData().altitude)
+	}
+}
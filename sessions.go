@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sessionDirectoryName = "sessions"
+	sessionExtension     = ".session"
+	sessionHeaderPrefix  = "# "
+)
+
+// Session describes a recorded connection session. A session file starts with "# key: value" header lines followed by
+// one "<receive time>\t<raw line>" line per received message. The link statistics are recorded in between as
+// "# stats: <json>" lines
+type Session struct {
+	Path    string
+	Rocket  string
+	Host    string
+	Started time.Time
+	Samples int
+	Size    int64
+	Link    LinkStats // Last link statistics recorded with the session
+}
+
+type SessionSample struct {
+	Received time.Time
+	Raw      string
+}
+
+type LoadedSession struct {
+	Session Session
+	Samples []SessionSample
+	Stats   []LinkStats
+}
+
+// sessionRecorder appends every message of one connection session to its own file. The file is opened in append-only
+// mode and synced to disk according to the SessionSync setting, so a crash loses at most the unsynced tail
+type sessionRecorder struct {
+	file     *os.File
+	path     string
+	lastSync time.Time
+	mu       sync.Mutex
+}
+
+func sessionDirectory(App fyne.App) (string, error) {
+	directory, err := flightDirectory(App)
+	if err != nil {
+		return "", err
+	}
+	directory = filepath.Join(directory, sessionDirectoryName)
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+	return directory, nil
+}
+
+func newSessionRecorder(App fyne.App, profile RocketProfile) (*sessionRecorder, error) {
+	directory, err := sessionDirectory(App)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	name := strings.ReplaceAll(strings.ToLower(profile.Name), " ", "-")
+	path := filepath.Join(directory, fmt.Sprintf("session-%s-%s%s", name, started.Format("20060102-150405.000"), sessionExtension))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := &sessionRecorder{file: file, path: path, lastSync: started}
+	header := sessionHeaderPrefix + "rocket: " + profile.Name + "\n" +
+		sessionHeaderPrefix + "host: " + profile.RocketHost + "\n" +
+		sessionHeaderPrefix + "started: " + started.Format(time.RFC3339Nano) + "\n"
+	if _, err := file.WriteString(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func (recorder *sessionRecorder) record(received time.Time, raw string) error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	line := received.Format(time.RFC3339Nano) + "\t" + strings.NewReplacer("\r", "", "\n", " ").Replace(strings.TrimRight(raw, "\r\n")) + "\n"
+	if _, err := recorder.file.WriteString(line); err != nil {
+		return err
+	}
+
+	switch getSettings().SessionSync {
+	case SessionSyncSample:
+		return recorder.file.Sync()
+	case SessionSyncSecond:
+		if received.Sub(recorder.lastSync) >= time.Second {
+			recorder.lastSync = received
+			return recorder.file.Sync()
+		}
+	}
+	return nil
+}
+
+func (recorder *sessionRecorder) recordStats(stats LinkStats) error {
+	content, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	_, err = recorder.file.WriteString(sessionHeaderPrefix + "stats: " + string(content) + "\n")
+	return err
+}
+
+func (recorder *sessionRecorder) close() error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return errors.Join(recorder.file.Sync(), recorder.file.Close())
+}
+
+func listSessions(App fyne.App) ([]Session, error) {
+	directory, err := sessionDirectory(App)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(directory, "*"+sessionExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	for _, path := range paths {
+		loaded, err := loadSession(path)
+		if err != nil {
+			netLogger.Println("Skipping session "+path+":", err)
+			continue
+		}
+		sessions = append(sessions, loaded.Session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Started.After(sessions[j].Started) })
+	return sessions, nil
+}
+
+// loadSession reads a session file. Lines that can't be parsed, e.g. a partially written last line after a crash, are
+// skipped
+func loadSession(path string) (LoadedSession, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return LoadedSession{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return LoadedSession{}, err
+	}
+	loaded := LoadedSession{Session: Session{Path: path, Size: info.Size(), Started: info.ModTime()}}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, sessionHeaderPrefix) {
+			key, value, _ := strings.Cut(strings.TrimPrefix(line, sessionHeaderPrefix), ": ")
+			switch key {
+			case "rocket":
+				loaded.Session.Rocket = value
+			case "host":
+				loaded.Session.Host = value
+			case "started":
+				if started, err := time.Parse(time.RFC3339Nano, value); err == nil {
+					loaded.Session.Started = started
+				}
+			case "stats":
+				var stats LinkStats
+				if err := json.Unmarshal([]byte(value), &stats); err == nil {
+					loaded.Stats = append(loaded.Stats, stats)
+					loaded.Session.Link = stats
+				}
+			}
+			continue
+		}
+
+		timestamp, raw, ok := strings.Cut(line, "\t")
+		received, err := time.Parse(time.RFC3339Nano, timestamp)
+		if !ok || err != nil {
+			continue
+		}
+		loaded.Samples = append(loaded.Samples, SessionSample{Received: received, Raw: raw})
+	}
+	loaded.Session.Samples = len(loaded.Samples)
+	return loaded, scanner.Err()
+}
+
+// telemetry parses the recorded frames, applying the calibration of the session's rocket if its profile still exists.
+// It returns the samples with their time since the first sample. Frames that can't be parsed are skipped and reported
+// in the error
+func (loaded LoadedSession) telemetry() (Log, []time.Duration, error) {
+	profile, hasProfile := findProfile(loaded.Session.Rocket)
+	parser := newTelemetryParser()
+	var log Log
+	var offsets []time.Duration
+	var start time.Time
+	var errs []error
+	for _, sample := range loaded.Samples {
+		data, ok, err := parser.Parse(sample.Raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sample.Received.Format(time.RFC3339Nano), err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if hasProfile {
+			data = profile.Calibration.apply(data)
+		}
+		data.source = loaded.Session.Rocket
+		if len(log) == 0 {
+			start = sample.Received
+		}
+		log = append(log, data)
+		offsets = append(offsets, sample.Received.Sub(start))
+	}
+	return log, offsets, errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"time"
+)
+
+func sessionsTab(App fyne.App, window fyne.Window, openAnalysis func(), openControl func()) fyne.CanvasObject {
+	var sessions []Session
+	selected := -1
+
+	list := widget.NewList(
+		func() int { return len(sessions) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			session := sessions[id]
+			item.(*widget.Label).SetText(fmt.Sprintf("%s  %s  %d samples  %.1f kB  %d lost",
+				session.Started.Format(time.DateTime), session.Rocket, session.Samples, float64(session.Size)/1024, session.Link.Lost))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) { selected = id }
+	list.OnUnselected = func(widget.ListItemID) { selected = -1 }
+
+	refresh := func() {
+		loaded, err := listSessions(App)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		sessions = loaded
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+	}
+
+	refreshButton := widget.NewButton("Refresh", refresh)
+	load := func() (LoadedSession, bool) {
+		if selected < 0 || selected >= len(sessions) {
+			dialog.ShowError(errors.New("select a session first"), window)
+			return LoadedSession{}, false
+		}
+		loaded, err := loadSession(sessions[selected].Path)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return LoadedSession{}, false
+		}
+		return loaded, true
+	}
+
+	openButton := widget.NewButton("Open in Analysis", func() {
+		if loaded, ok := load(); ok {
+			go ps.Pub(loaded, "session")
+			openAnalysis()
+		}
+	})
+	replayButton := widget.NewButton("Replay", func() {
+		if loaded, ok := load(); ok {
+			log, offsets, err := loaded.telemetry()
+			if err != nil {
+				netLogger.Println("Skipped invalid session frames:", err)
+			}
+			go replay.Load(log, offsets)
+			openControl()
+		}
+	})
+
+	go func() {
+		selectedTabChannel := ps.Sub("selectedTab")
+		for selectedTab := range selectedTabChannel {
+			if selectedTab.(*container.TabItem).Text == "Sessions" {
+				refresh()
+			}
+		}
+	}()
+
+	return container.NewBorder(container.NewGridWithColumns(3, refreshButton, openButton, replayButton), nil, nil, nil, list)
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"FlightControl/ThreeDView"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"io"
+	"log"
+	"math"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+)
+
+const (
+	TelemetryEncodingCSV    = "csv"
+	TelemetryEncodingBinary = "binary"
+)
+
+const (
+	SessionSyncSample = "sample"
+	SessionSyncSecond = "second"
+	SessionSyncClose  = "close"
+)
+
+const (
+	feetPerMeter = 3.28084
+	mphPerMps    = 2.23694
+)
+
+type Settings struct {
+	RocketHost           string  `json:"rocketHost"`
+	BaseStationHost      string  `json:"baseStationHost"`
+	WebSocketPath        string  `json:"webSocketPath"`
+	ReconnectInterval    float64 `json:"reconnectInterval"`
+	ReconnectAttempts    int     `json:"reconnectAttempts"`
+	TelemetryEncoding    string  `json:"telemetryEncoding"`
+	Units                string  `json:"units"`
+	FPSCap               int     `json:"fpsCap"`
+	ResolutionFactor     float64 `json:"resolutionFactor"`
+	LowVoltageAlarm      float64 `json:"lowVoltageAlarm"`
+	MaxAccelerationAlarm float64 `json:"maxAccelerationAlarm"`
+	LogDirectory         string  `json:"logDirectory"`
+	SessionSync          string  `json:"sessionSync"`
+}
+
+type settingField struct {
+	key     string
+	label   string
+	group   string
+	choices []string
+	get     func(Settings) string
+	set     func(*Settings, string) error
+}
+
+var settingsSchema = []settingField{
+	stringSetting("WaRaIP", "Rocket host", "Connection", func(s *Settings) *string { return &s.RocketHost }, validateHost),
+	stringSetting("BaseStationIP", "Base station host", "Connection", func(s *Settings) *string { return &s.BaseStationHost }, validateHost),
+	stringSetting("WebSocketPath", "WebSocket path", "Connection", func(s *Settings) *string { return &s.WebSocketPath }, validateWebSocketPath),
+	floatSetting("ReconnectInterval", "Reconnect interval (s)", "Connection", func(s *Settings) *float64 { return &s.ReconnectInterval }, 0.1, 600),
+	intSetting("ReconnectAttempts", "Reconnect attempts (0 = forever)", "Connection", func(s *Settings) *int { return &s.ReconnectAttempts }, 0, 10000),
+	choiceSetting("TelemetryEncoding", "Telemetry encoding", "Connection", func(s *Settings) *string { return &s.TelemetryEncoding }, []string{TelemetryEncodingCSV, TelemetryEncodingBinary}),
+	choiceSetting("Units", "Units", "Display", func(s *Settings) *string { return &s.Units }, []string{UnitsMetric, UnitsImperial}),
+	intSetting("FPSCap", "3D FPS cap", "Display", func(s *Settings) *int { return &s.FPSCap }, 1, 240),
+	floatSetting("ResolutionFactor", "3D resolution factor", "Display", func(s *Settings) *float64 { return &s.ResolutionFactor }, 0.05, 1),
+	floatSetting("LowVoltageAlarm", "Low voltage alarm (V)", "Alarms", func(s *Settings) *float64 { return &s.LowVoltageAlarm }, 0, 100),
+	floatSetting("MaxAccelerationAlarm", "Acceleration alarm (m/s²)", "Alarms", func(s *Settings) *float64 { return &s.MaxAccelerationAlarm }, 0, 10000),
+	stringSetting("LogDirectory", "Log directory", "Storage", func(s *Settings) *string { return &s.LogDirectory }, validateLogDirectory),
+	choiceSetting("SessionSync", "Sync session recordings", "Storage", func(s *Settings) *string { return &s.SessionSync }, []string{SessionSyncSample, SessionSyncSecond, SessionSyncClose}),
+}
+
+var (
+	currentSettings Settings
+	settingsMu      sync.RWMutex
+)
+
+func defaultSettings() Settings {
+	settings := Settings{
+		WebSocketPath:        "/websocket",
+		ReconnectInterval:    5,
+		TelemetryEncoding:    TelemetryEncodingCSV,
+		Units:                UnitsMetric,
+		FPSCap:               60,
+		ResolutionFactor:     0.5,
+		LowVoltageAlarm:      6.5,
+		MaxAccelerationAlarm: 150,
+		SessionSync:          SessionSyncSecond,
+	}
+	if fyne.CurrentDevice().IsMobile() {
+		settings.FPSCap = 30
+		settings.ResolutionFactor = 0.3
+	}
+	return settings
+}
+
+func stringSetting(key, label, group string, field func(*Settings) *string, validate func(string) error) settingField {
+	return settingField{
+		key:   key,
+		label: label,
+		group: group,
+		get:   func(s Settings) string { return *field(&s) },
+		set: func(s *Settings, value string) error {
+			value = strings.TrimSpace(value)
+			if err := validate(value); err != nil {
+				return err
+			}
+			*field(s) = value
+			return nil
+		},
+	}
+}
+
+func choiceSetting(key, label, group string, field func(*Settings) *string, choices []string) settingField {
+	setting := stringSetting(key, label, group, field, func(value string) error {
+		for _, choice := range choices {
+			if value == choice {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(choices, ", "))
+	})
+	setting.choices = choices
+	return setting
+}
+
+func intSetting(key, label, group string, field func(*Settings) *int, min, max int) settingField {
+	return settingField{
+		key:   key,
+		label: label,
+		group: group,
+		get:   func(s Settings) string { return strconv.Itoa(*field(&s)) },
+		set: func(s *Settings, value string) error {
+			parsed, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return errors.New("must be a whole number")
+			}
+			if parsed < min || parsed > max {
+				return fmt.Errorf("must be between %d and %d", min, max)
+			}
+			*field(s) = parsed
+			return nil
+		},
+	}
+}
+
+func floatSetting(key, label, group string, field func(*Settings) *float64, min, max float64) settingField {
+	return settingField{
+		key:   key,
+		label: label,
+		group: group,
+		get:   func(s Settings) string { return strconv.FormatFloat(*field(&s), 'f', -1, 64) },
+		set: func(s *Settings, value string) error {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || math.IsNaN(parsed) {
+				return errors.New("must be a number")
+			}
+			if parsed < min || parsed > max {
+				return fmt.Errorf("must be between %g and %g", min, max)
+			}
+			*field(s) = parsed
+			return nil
+		},
+	}
+}
+
+func validateHost(value string) error {
+	if value == "" {
+		return nil
+	}
+	host, port := value, ""
+	if strings.Contains(value, ":") {
+		var err error
+		if host, port, err = net.SplitHostPort(value); err != nil {
+			return errors.New("must be host or host:port")
+		}
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return errors.New("port must be between 1 and 65535")
+		}
+	}
+	if host == "" || strings.ContainsAny(host, " /?#@") {
+		return errors.New("must be host or host:port")
+	}
+	return nil
+}
+
+func validateWebSocketPath(value string) error {
+	if !strings.HasPrefix(value, "/") {
+		return errors.New("must start with /")
+	}
+	return nil
+}
+
+func validateLogDirectory(value string) error {
+	if value != "" && !filepath.IsAbs(value) {
+		return errors.New("must be an absolute path")
+	}
+	return nil
+}
+
+func (s Settings) validate() error {
+	var errs []error
+	for _, field := range settingsSchema {
+		scratch := s
+		if err := field.set(&scratch, field.get(s)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field.label, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s Settings) reconnectInterval() time.Duration {
+	return time.Duration(s.ReconnectInterval * float64(time.Second))
+}
+
+func (s Settings) formatDistance(meters float64) string {
+	if s.Units == UnitsImperial {
+		return fmt.Sprintf("%.1f ft", meters*feetPerMeter)
+	}
+	return fmt.Sprintf("%.1f m", meters)
+}
+
+func (s Settings) formatSpeed(metersPerSecond float64) string {
+	if s.Units == UnitsImperial {
+		return fmt.Sprintf("%.1f mph", metersPerSecond*mphPerMps)
+	}
+	return fmt.Sprintf("%.1f m/s", metersPerSecond)
+}
+
+func (s Settings) alarms(data Data) []string {
+	var alarms []string
+	if data.voltage < s.LowVoltageAlarm {
+		alarms = append(alarms, fmt.Sprintf("Low voltage: %.2f V", data.voltage))
+	}
+	acceleration := math.Sqrt(data.xAcceleration*data.xAcceleration + data.yAcceleration*data.yAcceleration + data.zAcceleration*data.zAcceleration)
+	if acceleration > s.MaxAccelerationAlarm {
+		alarms = append(alarms, fmt.Sprintf("High acceleration: %.1f m/s²", acceleration))
+	}
+	return alarms
+}
+
+func loadSettings(App fyne.App) Settings {
+	settings := defaultSettings()
+	for _, field := range settingsSchema {
+		value := App.Preferences().String(field.key)
+		if value == "" {
+			continue
+		}
+		if err := field.set(&settings, value); err != nil {
+			log.Printf("Ignoring setting %s=%q: %v", field.key, value, err)
+		}
+	}
+	return settings
+}
+
+func (s Settings) store(App fyne.App) {
+	for _, field := range settingsSchema {
+		App.Preferences().SetString(field.key, field.get(s))
+	}
+}
+
+func initSettings(App fyne.App) {
+	settingsMu.Lock()
+	currentSettings = loadSettings(App)
+	settingsMu.Unlock()
+}
+
+func getSettings() Settings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return currentSettings
+}
+
+func applySettings(App fyne.App, settings Settings) error {
+	if err := settings.validate(); err != nil {
+		return err
+	}
+	settings.store(App)
+	settingsMu.Lock()
+	currentSettings = settings
+	settingsMu.Unlock()
+	ps.Pub(settings, "settings")
+	return nil
+}
+
+func hostLabel(host string) string {
+	if host == "" {
+		return "Not set"
+	}
+	return host
+}
+
+func watchViewSettings(threeDEnv *ThreeDView.ThreeDWidget) {
+	apply := func(settings Settings) {
+		threeDEnv.SetFPSCap(float64(settings.FPSCap))
+		threeDEnv.SetResolutionFactor(settings.ResolutionFactor)
+	}
+	apply(getSettings())
+	go func() {
+		settingsChannel := ps.Sub("settings")
+		for settings := range settingsChannel {
+			apply(settings.(Settings))
+		}
+	}()
+}
+
+func exportSettings(writer io.Writer, settings Settings) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(settings)
+}
+
+func importSettings(reader io.Reader) (Settings, error) {
+	settings := defaultSettings()
+	if err := json.NewDecoder(reader).Decode(&settings); err != nil {
+		return Settings{}, fmt.Errorf("reading settings: %w", err)
+	}
+	if err := settings.validate(); err != nil {
+		return Settings{}, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+type settingInput struct {
+	field  settingField
+	entry  *widget.Entry
+	choice *widget.Select
+}
+
+func (input settingInput) value() string {
+	if input.choice != nil {
+		return input.choice.Selected
+	}
+	return input.entry.Text
+}
+
+func (input settingInput) setValue(value string) {
+	if input.choice != nil {
+		input.choice.SetSelected(value)
+		return
+	}
+	input.entry.SetText(value)
+}
+
+func settingsTab(App fyne.App, window fyne.Window) fyne.CanvasObject {
+	var inputs []settingInput
+	var groups []string
+	forms := map[string]*widget.Form{}
+	for _, field := range settingsSchema {
+		field := field
+		input := settingInput{field: field}
+		var object fyne.CanvasObject
+		if field.choices != nil {
+			input.choice = widget.NewSelect(field.choices, nil)
+			object = input.choice
+		} else {
+			input.entry = widget.NewEntry()
+			input.entry.Validator = func(value string) error {
+				scratch := defaultSettings()
+				return field.set(&scratch, value)
+			}
+			object = input.entry
+		}
+		inputs = append(inputs, input)
+
+		if forms[field.group] == nil {
+			forms[field.group] = widget.NewForm()
+			groups = append(groups, field.group)
+		}
+		forms[field.group].Append(field.label, object)
+	}
+
+	fill := func(settings Settings) {
+		for _, input := range inputs {
+			input.setValue(input.field.get(settings))
+		}
+	}
+	collect := func() (Settings, error) {
+		settings := getSettings()
+		var errs []error
+		for _, input := range inputs {
+			if err := input.field.set(&settings, input.value()); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", input.field.label, err))
+			}
+		}
+		return settings, errors.Join(errs...)
+	}
+	apply := func(settings Settings) {
+		if err := applySettings(App, settings); err != nil {
+			dialog.ShowError(err, window)
+		}
+	}
+	fill(getSettings())
+
+	applyButton := widget.NewButton("Apply", func() {
+		settings, err := collect()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		apply(settings)
+	})
+	revertButton := widget.NewButton("Revert", func() { fill(getSettings()) })
+	defaultsButton := widget.NewButton("Defaults", func() { fill(defaultSettings()) })
+	importButton := widget.NewButton("Import", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			settings, err := importSettings(reader)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			apply(settings)
+		}, window)
+	})
+	exportButton := widget.NewButton("Export", func() {
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			if err := exportSettings(writer, getSettings()); err != nil {
+				writer.Close()
+				dialog.ShowError(err, window)
+				return
+			}
+			if err := writer.Close(); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+		saveDialog.SetFileName("flightcontrol-settings.json")
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+		saveDialog.Show()
+	})
+
+	go func() {
+		settingsChannel := ps.Sub("settings")
+		for settings := range settingsChannel {
+			fill(settings.(Settings))
+		}
+	}()
+
+	cards := container.NewVBox()
+	for _, group := range groups {
+		cards.Add(widget.NewCard(group, "", forms[group]))
+	}
+	buttons := container.NewGridWithColumns(5, applyButton, revertButton, defaultsButton, importButton, exportButton)
+	return container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(cards))
+}
+
+func showRocketHostDialog(App fyne.App, window fyne.Window) {
+	hostEntry := widget.NewEntry()
+	hostEntry.SetPlaceHolder("Enter host or host:port")
+	hostEntry.SetText(getSettings().RocketHost)
+	hostEntry.Validator = validateHost
+	dialog.ShowForm("Set WaRa IP", "OK", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("IP", hostEntry),
+	}, func(ok bool) {
+		if !ok {
+			return
+		}
+		settings := getSettings()
+		settings.RocketHost = hostEntry.Text
+		if err := applySettings(App, settings); err != nil {
+			dialog.ShowError(err, window)
+		}
+	}, window)
+}
@@ -5,43 +5,155 @@ import (
 	"FlightControl/ThreeDView/camera"
 	"FlightControl/ThreeDView/object"
 	"FlightControl/ThreeDView/types"
+	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 	"image/color"
+	"sync"
 )
 
-func simulationTab() fyne.CanvasObject {
+const simulationTPS = 1600
+
+var cameraModes = []string{"Orbit", "Chase", "Ground observer", "Free fly"}
+
+func newCameraControllers(rocket *Rocket) map[string]camera.Controller {
+	return map[string]camera.Controller{
+		"Orbit":           camera.NewOrbitController(rocket),
+		"Chase":           camera.NewChaseController(rocket),
+		"Ground observer": camera.NewGroundObserverController(rocket, types.Point3D{X: 1500, Y: 1500, Z: 170}),
+		"Free fly":        camera.NewFreeFlyController(),
+	}
+}
+
+func simulationTab(window fyne.Window) fyne.CanvasObject {
 	threeDEnv := ThreeDView.NewThreeDWidget()
 	threeDEnv.Hide()
-	threeDEnv.SetBackgroundColor(color.RGBA{R: 135, G: 206, B: 235, A: 255})
-	threeDEnv.SetTPSCap(1600)
+	threeDEnv.SetSkyGradient(skyHorizonColor, skyZenithColor)
+	threeDEnv.SetTPSCap(simulationTPS)
+	watchViewSettings(threeDEnv)
+	var launchSite *LaunchSite
 	if fyne.CurrentDevice().IsMobile() {
-		threeDEnv.SetFPSCap(30)
-		threeDEnv.SetResolutionFactor(0.3)
-		object.NewPlane(1000, types.Point3D{X: 0, Y: 0, Z: 0}, types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0}, color.RGBA{G: 255, A: 255}, threeDEnv, 4)
+		launchSite = NewLaunchSite(1000, 4, []float64{4}, threeDEnv)
 	} else {
-		threeDEnv.SetResolutionFactor(0.5)
-		object.NewPlane(5000, types.Point3D{X: 0, Y: 0, Z: 0}, types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0}, color.RGBA{G: 255, A: 255}, threeDEnv, 5)
+		launchSite = NewLaunchSite(5000, 5, []float64{10, 20}, threeDEnv)
 	}
 
 	rocket := NewTwoStageRocket(types.Point3D{X: 0, Y: 0, Z: 0}, types.Rotation3D{Roll: 0, Pitch: 0, Yaw: 0}, threeDEnv)
+	trail := NewFlightTrail(types.Point3D{}, threeDEnv)
+	watchProfileModel(rocket)
+	threeDEnv.AddHUDElement(ThreeDView.HUDTopLeft, func() string {
+		return fmt.Sprintf("Altitude: %.1f m", float64(rocket.GetPosition().Z/altitudeScale))
+	})
+	threeDEnv.SetShowFPS(true)
 
 	envCamera := camera.NewCamera(types.Point3D{Y: 500, Z: 200}, types.Rotation3D{})
-	orbitController := camera.NewOrbitController(rocket)
-	envCamera.SetController(orbitController)
+	cameraControllers := newCameraControllers(rocket)
+	envCamera.SetController(cameraControllers["Orbit"])
 	threeDEnv.SetCamera(&envCamera)
 
+	// The flight is simulated with the physical parameters of the profile that was active at the launch
+	flight := newFlightModel(getProfile())
+	var flightMu sync.Mutex
 	threeDEnv.RegisterTickMethod(func() {
-		rocket.Move(types.Point3D{X: 0, Y: 0, Z: 1})
-		orbitController.Update()
+		flightMu.Lock()
+		flight.step(1.0 / simulationTPS)
+		data := flight.data()
+		flightMu.Unlock()
+		position := types.Point3D{Z: types.Unit(data.altitude) * altitudeScale}
+		rocket.SetPosition(position)
+		trail.AddPosition(position, data)
+		envCamera.Controller.Update()
+	})
+
+	sampleLabel := widget.NewLabel("Tap the rocket or the trail to inspect a sample")
+	sampleLabel.Wrapping = fyne.TextWrapWord
+	hoverLabel := object.NewLabel("", types.Point3D{}, color.White, threeDEnv)
+	hoverLabel.SetHidden(true)
+	inspect := func(position fyne.Position, hit ThreeDView.Hit, ok bool) (Data, types.Point3D, bool) {
+		if ok && rocket.Contains(hit.Object) {
+			return rocket.GetData(), hit.Position, true
+		}
+		return trail.NearestSample(position, 10, threeDEnv.ScreenPosition)
+	}
+	// The simulated rocket doesn't receive telemetry, so there is no sample to show for it
+	noTelemetry := func(hit ThreeDView.Hit, ok bool) bool {
+		return ok && rocket.Contains(hit.Object) && !rocket.HasData()
+	}
+	threeDEnv.SetOnTapped(func(position fyne.Position, hit ThreeDView.Hit, ok bool) {
+		if noTelemetry(hit, ok) {
+			sampleLabel.SetText("No telemetry for the simulated rocket")
+			return
+		}
+		if data, _, found := inspect(position, hit, ok); found {
+			sampleLabel.SetText(data.describe())
+		}
+	})
+	threeDEnv.SetOnHovered(func(position fyne.Position, hit ThreeDView.Hit, ok bool) {
+		if noTelemetry(hit, ok) {
+			hoverLabel.SetHidden(false)
+			hoverLabel.SetPosition(hit.Position)
+			hoverLabel.SetText("No telemetry")
+			return
+		}
+		data, samplePosition, found := inspect(position, hit, ok)
+		hoverLabel.SetHidden(!found)
+		hoverLabel.SetPosition(samplePosition)
+		hoverLabel.SetText(fmt.Sprintf("%.1f m, %s", data.altitude, data.status))
 	})
 
 	separateButton := widget.NewButton("Separate", func() {
 		rocket.SeparateStage()
 	})
 	separateButton.Resize(fyne.NewSize(100, 50))
-	buttonContainer := container.NewVBox(separateButton)
+	launchButton := widget.NewButton("Launch", func() {
+		flightMu.Lock()
+		flight = newFlightModel(getProfile())
+		flightMu.Unlock()
+		rocket.SetModel(getProfile().Model)
+		trail.Clear()
+	})
+	trailColorSelect := widget.NewSelect([]string{"Color trail by phase", "Color trail by speed"}, func(selected string) {
+		if selected == "Color trail by speed" {
+			trail.SetColorMode(TrailColorBySpeed)
+		} else {
+			trail.SetColorMode(TrailColorByPhase)
+		}
+	})
+	trailColorSelect.SetSelectedIndex(0)
+	cameraSelect := widget.NewSelect(cameraModes, func(selected string) {
+		envCamera.SetController(cameraControllers[selected])
+	})
+	cameraSelect.SetSelected("Orbit")
+	loadTerrainButton := widget.NewButton("Load terrain", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			if err := launchSite.LoadTerrain(reader.URI().Name(), reader); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+	})
+	windDirection, windSpeed := 0.0, 0.0
+	windDirectionSlider := widget.NewSlider(0, 359)
+	windDirectionSlider.OnChanged = func(direction float64) {
+		windDirection = direction
+		launchSite.SetWind(types.Degrees(windDirection), windSpeed)
+	}
+	windSpeedSlider := widget.NewSlider(0, 20)
+	windSpeedSlider.Step = 0.5
+	windSpeedSlider.OnChanged = func(speed float64) {
+		windSpeed = speed
+		launchSite.SetWind(types.Degrees(windDirection), windSpeed)
+	}
+	windForm := widget.NewForm(
+		widget.NewFormItem("Wind direction", windDirectionSlider),
+		widget.NewFormItem("Wind speed", windSpeedSlider),
+	)
+	buttonContainer := container.NewVBox(sampleLabel, launchButton, separateButton, trailColorSelect, cameraSelect, loadTerrainButton, windForm)
 
 	go func() {
 		selectedTabChannel := ps.Sub("selectedTab")
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	schemaFramePrefix   = "#schema"
+	legacySchemaVersion = 1
+)
+
+type SchemaField struct {
+	Name string
+	Unit string
+}
+
+// TelemetrySchema describes the fields of the telemetry frames sent by a rocket. A rocket announces its schema with a
+// schema frame "#schema,<version>,<name>[:<unit>],..." before the first data frame. Announcing only the version is
+// enough for schemas that are already registered
+type TelemetrySchema struct {
+	Version int
+	Fields  []SchemaField
+}
+
+var legacySchema = TelemetrySchema{
+	Version: legacySchemaVersion,
+	Fields: []SchemaField{
+		{Name: "timestamp"},
+		{Name: "altitude", Unit: "m"},
+		{Name: "max-altitude", Unit: "m"},
+		{Name: "status"},
+		{Name: "voltage", Unit: "V"},
+		{Name: "x-rotation", Unit: "°"},
+		{Name: "y-rotation", Unit: "°"},
+		{Name: "z-rotation", Unit: "°"},
+		{Name: "x-rotation-speed", Unit: "°/s"},
+		{Name: "y-rotation-speed", Unit: "°/s"},
+		{Name: "z-rotation-speed", Unit: "°/s"},
+		{Name: "x-acceleration", Unit: "m/s²"},
+		{Name: "y-acceleration", Unit: "m/s²"},
+		{Name: "z-acceleration", Unit: "m/s²"},
+		{Name: "x-velocity", Unit: "m/s"},
+		{Name: "y-velocity", Unit: "m/s"},
+		{Name: "z-velocity", Unit: "m/s"},
+	},
+}
+
+var (
+	schemaRegistry   = map[int]TelemetrySchema{legacySchemaVersion: legacySchema}
+	schemaRegistryMu sync.RWMutex
+)
+
+// telemetryFields maps the known numeric field names to the Data they are parsed into. Values are converted to the unit
+// of the field in legacySchema. Fields a schema doesn't contain keep the value of defaultTelemetry, fields that aren't
+// known are ignored
+var telemetryFields = map[string]func(*Data) *float64{
+	"altitude":         func(data *Data) *float64 { return &data.altitude },
+	"max-altitude":     func(data *Data) *float64 { return &data.maxAltitude },
+	"voltage":          func(data *Data) *float64 { return &data.voltage },
+	"x-rotation":       func(data *Data) *float64 { return &data.xRotation },
+	"y-rotation":       func(data *Data) *float64 { return &data.yRotation },
+	"z-rotation":       func(data *Data) *float64 { return &data.zRotation },
+	"x-rotation-speed": func(data *Data) *float64 { return &data.xRotationSpeed },
+	"y-rotation-speed": func(data *Data) *float64 { return &data.yRotationSpeed },
+	"z-rotation-speed": func(data *Data) *float64 { return &data.zRotationSpeed },
+	"x-acceleration":   func(data *Data) *float64 { return &data.xAcceleration },
+	"y-acceleration":   func(data *Data) *float64 { return &data.yAcceleration },
+	"z-acceleration":   func(data *Data) *float64 { return &data.zAcceleration },
+	"x-velocity":       func(data *Data) *float64 { return &data.xVelocity },
+	"y-velocity":       func(data *Data) *float64 { return &data.yVelocity },
+	"z-velocity":       func(data *Data) *float64 { return &data.zVelocity },
+}
+
+var telemetryTextFields = map[string]func(*Data, string) error{
+	"timestamp": func(data *Data, value string) error {
+		data.timestamp = value
+		return nil
+	},
+	"status": func(data *Data, value string) error {
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index > Status(StatusError).toIndex() {
+			return fmt.Errorf("invalid status index %q", value)
+		}
+		data.status = toStatus(value)
+		return nil
+	},
+}
+
+var unitFactors = map[[2]string]float64{
+	{"ft", "m"}:      0.3048,
+	{"km", "m"}:      1000,
+	{"mV", "V"}:      0.001,
+	{"rad", "°"}:     180 / math.Pi,
+	{"rad/s", "°/s"}: 180 / math.Pi,
+	{"g", "m/s²"}:    standardGravity,
+	{"km/h", "m/s"}:  1 / 3.6,
+	{"ft/s", "m/s"}:  0.3048,
+}
+
+// timestampUnits are the units the timestamp field can be declared in. Without a unit it's in seconds
+var timestampUnits = map[string]time.Duration{
+	"":   time.Second,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+}
+
+var defaultTelemetry = Data{status: StatusIdle}
+
+func canonicalUnit(name string) string {
+	for _, field := range legacySchema.Fields {
+		if field.Name == name {
+			return field.Unit
+		}
+	}
+	return ""
+}
+
+// unitFactor returns the factor that converts values of a field to its canonical unit. Fields without a unit are
+// assumed to use the canonical unit
+func unitFactor(field SchemaField) (float64, error) {
+	canonical := canonicalUnit(field.Name)
+	if field.Unit == "" || field.Unit == canonical || telemetryFields[field.Name] == nil {
+		return 1, nil
+	}
+	factor, ok := unitFactors[[2]string{field.Unit, canonical}]
+	if !ok {
+		return 0, fmt.Errorf("can't convert %s of %s to %s", field.Unit, field.Name, canonical)
+	}
+	return factor, nil
+}
+
+func (schema TelemetrySchema) validate() error {
+	if schema.Version < 1 {
+		return fmt.Errorf("invalid schema version %d", schema.Version)
+	}
+	if len(schema.Fields) == 0 {
+		return fmt.Errorf("schema %d has no fields", schema.Version)
+	}
+	seen := map[string]bool{}
+	for _, field := range schema.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("schema %d has a field without a name", schema.Version)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("schema %d has the field %s twice", schema.Version, field.Name)
+		}
+		seen[field.Name] = true
+		if _, ok := timestampUnits[field.Unit]; field.Name == "timestamp" && !ok {
+			return fmt.Errorf("schema %d: unsupported timestamp unit %s", schema.Version, field.Unit)
+		}
+		if _, err := unitFactor(field); err != nil {
+			return fmt.Errorf("schema %d: %w", schema.Version, err)
+		}
+	}
+	return nil
+}
+
+func (schema TelemetrySchema) frame() string {
+	parts := []string{schemaFramePrefix, strconv.Itoa(schema.Version)}
+	for _, field := range schema.Fields {
+		if field.Unit == "" {
+			parts = append(parts, field.Name)
+		} else {
+			parts = append(parts, field.Name+":"+field.Unit)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func registerSchema(schema TelemetrySchema) error {
+	if err := schema.validate(); err != nil {
+		return err
+	}
+	schemaRegistryMu.Lock()
+	defer schemaRegistryMu.Unlock()
+	if registered, ok := schemaRegistry[schema.Version]; ok && !slices.Equal(registered.Fields, schema.Fields) {
+		return fmt.Errorf("schema %d is already registered with different fields", schema.Version)
+	}
+	schemaRegistry[schema.Version] = schema
+	return nil
+}
+
+func lookupSchema(version int) (TelemetrySchema, bool) {
+	schemaRegistryMu.RLock()
+	defer schemaRegistryMu.RUnlock()
+	schema, ok := schemaRegistry[version]
+	return schema, ok
+}
+
+func isSchemaFrame(frame string) bool {
+	return strings.HasPrefix(frame, schemaFramePrefix)
+}
+
+func parseSchemaFrame(frame string) (TelemetrySchema, error) {
+	record, err := csv.NewReader(strings.NewReader(frame)).Read()
+	if err != nil {
+		return TelemetrySchema{}, fmt.Errorf("reading schema frame: %w", err)
+	}
+	if len(record) < 2 || record[0] != schemaFramePrefix {
+		return TelemetrySchema{}, errors.New("schema frame without version")
+	}
+	version, err := strconv.Atoi(record[1])
+	if err != nil {
+		return TelemetrySchema{}, fmt.Errorf("invalid schema version %q", record[1])
+	}
+	if len(record) == 2 {
+		schema, ok := lookupSchema(version)
+		if !ok {
+			return TelemetrySchema{}, fmt.Errorf("unknown schema version %d", version)
+		}
+		return schema, nil
+	}
+
+	schema := TelemetrySchema{Version: version}
+	for _, declaration := range record[2:] {
+		name, unit, _ := strings.Cut(strings.TrimSpace(declaration), ":")
+		schema.Fields = append(schema.Fields, SchemaField{Name: name, Unit: unit})
+	}
+	if err := registerSchema(schema); err != nil {
+		return TelemetrySchema{}, err
+	}
+	return schema, nil
+}
+
+// TelemetryParser parses the frames of one connection or file. It starts with the legacy 17 field schema and switches
+// to the schema of every schema frame it parses. Binary frames are passed as text with binaryFramePrefix
+type TelemetryParser struct {
+	schema        TelemetrySchema
+	factors       []float64
+	timestampUnit time.Duration
+	sequence      uint32 // Sequence number of the last frame if it was a binary frame
+	hasSequence   bool
+	binary        bool // Whether the last frame was a binary frame
+	validator     telemetryValidator
+	track         horizontalTrack
+}
+
+func newTelemetryParser() *TelemetryParser {
+	parser := &TelemetryParser{}
+	parser.setSchema(legacySchema)
+	return parser
+}
+
+func (parser *TelemetryParser) setSchema(schema TelemetrySchema) {
+	parser.schema = schema
+	parser.factors = make([]float64, len(schema.Fields))
+	parser.timestampUnit = timestampUnits[""]
+	for i, field := range schema.Fields {
+		parser.factors[i], _ = unitFactor(field)
+		if field.Name == "timestamp" {
+			parser.timestampUnit = timestampUnits[field.Unit]
+		}
+	}
+}
+
+func (parser *TelemetryParser) Schema() TelemetrySchema {
+	return parser.schema
+}
+
+// Sequence returns the sequence number of the last frame. Only binary frames have sequence numbers
+func (parser *TelemetryParser) Sequence() (uint32, bool) {
+	return parser.sequence, parser.hasSequence
+}
+
+// TimestampResolution returns the unit of the timestamp of the last frame
+func (parser *TelemetryParser) TimestampResolution() time.Duration {
+	if parser.binary {
+		return binaryTimestampUnit
+	}
+	return parser.timestampUnit
+}
+
+// RocketTime returns the timestamp of data, which was parsed by this parser, as time on the rocket's clock
+func (parser *TelemetryParser) RocketTime(data Data) (time.Duration, bool) {
+	timestamp, err := strconv.ParseFloat(data.timestamp, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(timestamp * float64(parser.TimestampResolution())), true
+}
+
+func (parser *TelemetryParser) parseBinary(text string) (Data, bool, error) {
+	frame, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, binaryFramePrefix))
+	if err != nil {
+		return Data{}, false, fmt.Errorf("decoding binary frame: %w", err)
+	}
+	data, sequence, err := decodeBinaryFrame(frame)
+	if err != nil {
+		return Data{}, false, err
+	}
+	parser.sequence = sequence
+	parser.hasSequence = true
+	parser.binary = true
+	parser.validate(&data, nil)
+	return data, true, nil
+}
+
+// Parse parses one frame. For schema frames it returns false and no data. Frames that can't be read as a whole return
+// an error, problems with single values are flagged in the quality of the returned data instead
+func (parser *TelemetryParser) Parse(frame string) (Data, bool, error) {
+	frame = strings.TrimRight(frame, "\r\n")
+	if strings.HasPrefix(frame, binaryFramePrefix) {
+		return parser.parseBinary(frame)
+	}
+	if isSchemaFrame(frame) {
+		schema, err := parseSchemaFrame(frame)
+		if err != nil {
+			return Data{}, false, err
+		}
+		parser.setSchema(schema)
+		return Data{}, false, nil
+	}
+
+	parser.hasSequence = false
+	parser.binary = false
+	record, err := csv.NewReader(strings.NewReader(frame)).Read()
+	if err != nil {
+		return Data{}, false, fmt.Errorf("reading frame: %w", err)
+	}
+	if len(record) != len(parser.schema.Fields) {
+		return Data{}, false, fmt.Errorf("schema %d expects %d fields, the frame has %d", parser.schema.Version, len(parser.schema.Fields), len(record))
+	}
+
+	data := defaultTelemetry
+	invalid := map[string]bool{}
+	for i, field := range parser.schema.Fields {
+		if set, ok := telemetryTextFields[field.Name]; ok {
+			if err := set(&data, record[i]); err != nil {
+				data.flag(QualityUnparseable, fmt.Sprintf("%s: %s", field.Name, err))
+				invalid[field.Name] = true
+			}
+		} else if value, ok := telemetryFields[field.Name]; ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+			if err != nil {
+				data.flag(QualityUnparseable, fmt.Sprintf("%s: invalid number %q", field.Name, record[i]))
+				invalid[field.Name] = true
+				continue
+			}
+			*value(&data) = parsed * parser.factors[i]
+		}
+	}
+	if _, ok := parser.RocketTime(data); !ok && data.timestamp != "" {
+		data.flag(QualityUnparseable, fmt.Sprintf("timestamp: invalid number %q", data.timestamp))
+		invalid["timestamp"] = true
+	}
+	parser.validate(&data, invalid)
+	return data, true, nil
+}
+
+// validate flags the problems of a parsed frame and replaces the invalid values, see telemetryValidator. It then sets
+// the derived rocket time and horizontal position of the sample
+func (parser *TelemetryParser) validate(data *Data, invalid map[string]bool) {
+	rocketTime, hasTime := parser.RocketTime(*data)
+	data.rocketTime, data.hasRocketTime = parser.validator.validate(data, invalid, rocketTime, hasTime)
+	parser.track.advance(data)
+}
+
+// horizontalTrack integrates the horizontal velocity of the samples over their timestamps into the distance from the
+// launch site. Longer gaps than maxTrackInterval, e.g. after a reconnect, are not integrated
+type horizontalTrack struct {
+	last    Data
+	hasLast bool
+}
+
+const maxTrackInterval = 5 * time.Second
+
+func (track *horizontalTrack) advance(data *Data) {
+	if track.hasLast {
+		data.xPosition, data.yPosition = track.last.xPosition, track.last.yPosition
+		interval := data.rocketTime - track.last.rocketTime
+		if data.hasRocketTime && track.last.hasRocketTime && interval > 0 && interval <= maxTrackInterval {
+			data.xPosition += (track.last.xVelocity + data.xVelocity) / 2 * interval.Seconds()
+			data.yPosition += (track.last.yVelocity + data.yVelocity) / 2 * interval.Seconds()
+		}
+	}
+	track.last = *data
+	track.hasLast = true
+}
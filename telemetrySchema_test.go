@@ -0,0 +1,181 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// forgetSchema removes a schema a test registered, so the tests don't depend on each other through the registry
+func forgetSchema(t *testing.T, version int) {
+	t.Cleanup(func() {
+		schemaRegistryMu.Lock()
+		delete(schemaRegistry, version)
+		schemaRegistryMu.Unlock()
+	})
+}
+
+// parseFrames parses frames with a new parser and returns the data of the last one
+func parseFrames(t *testing.T, frames ...string) (Data, error) {
+	t.Helper()
+	parser := newTelemetryParser()
+	var data Data
+	for _, frame := range frames {
+		parsed, ok, err := parser.Parse(frame)
+		if err != nil {
+			return Data{}, err
+		}
+		if ok {
+			data = parsed
+		}
+	}
+	return data, nil
+}
+
+func TestParseLegacyFrame(t *testing.T) {
+	data, err := parseFrames(t, "1.5,120.5,130,3,7.4,1,2,3,4,5,6,0.1,0.2,9.8,1.1,1.2,25\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.quality != 0 {
+		t.Errorf("unexpected warnings %v", data.warnings)
+	}
+	if data.timestamp != "1.5" || data.status != StatusPoweredAscent {
+		t.Errorf("timestamp %s and status %s", data.timestamp, data.status)
+	}
+	expected := []float64{120.5, 130, 7.4, 1, 2, 3, 4, 5, 6, 0.1, 0.2, 9.8, 1.1, 1.2, 25}
+	for i, value := range binaryValues(&data) {
+		if *value != expected[i] {
+			t.Errorf("value %d is %v, expected %v", i, *value, expected[i])
+		}
+	}
+}
+
+func TestParseSchemaFrames(t *testing.T) {
+	forgetSchema(t, 901)
+	forgetSchema(t, 902)
+	forgetSchema(t, 903)
+
+	tests := []struct {
+		name   string
+		frames []string
+		check  func(Data) bool
+	}{
+		{
+			"missing fields keep their defaults",
+			[]string{"#schema,901,timestamp,altitude:m", "2,50"},
+			func(data Data) bool {
+				return data.altitude == 50 && data.status == defaultTelemetry.status && data.voltage == 0 && data.zVelocity == 0
+			},
+		},
+		{
+			"fields in a different order",
+			[]string{"#schema,902,altitude,status,timestamp", "12,5,3"},
+			func(data Data) bool {
+				return data.altitude == 12 && data.status == StatusDescent && data.timestamp == "3"
+			},
+		},
+		{
+			"unknown fields are ignored",
+			[]string{"#schema,903,timestamp,humidity:%,altitude", "1,80,7"},
+			func(data Data) bool { return data.altitude == 7 && data.quality == 0 },
+		},
+		{
+			"version only refers to a registered schema",
+			[]string{"#schema,901,timestamp,altitude:m", "#schema,1", "#schema,901", "3,60"},
+			func(data Data) bool { return data.altitude == 60 },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseFrames(t, test.frames...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !test.check(data) {
+				t.Errorf("unexpected data %+v", data)
+			}
+		})
+	}
+}
+
+func TestParseUnitConversion(t *testing.T) {
+	forgetSchema(t, 904)
+	data, err := parseFrames(t,
+		"#schema,904,timestamp:ms,altitude:ft,voltage:mV,x-rotation:rad,z-rotation-speed:rad/s,z-acceleration:g,z-velocity:km/h",
+		"1500,100,7400,3.141592653589793,1,2,36",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		value    float64
+		expected float64
+	}{
+		{"altitude", data.altitude, 30.48},
+		{"voltage", data.voltage, 7.4},
+		{"x-rotation", data.xRotation, 180},
+		{"z-rotation-speed", data.zRotationSpeed, 180 / math.Pi},
+		{"z-acceleration", data.zAcceleration, 2 * standardGravity},
+		{"z-velocity", data.zVelocity, 10},
+	}
+	for _, test := range tests {
+		if math.Abs(test.value-test.expected) > 1e-9 {
+			t.Errorf("%s is %v, expected %v", test.name, test.value, test.expected)
+		}
+	}
+	if data.rocketTime.Seconds() != 1.5 || !data.hasRocketTime {
+		t.Errorf("rocket time %s, expected 1.5s", data.rocketTime)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	forgetSchema(t, 905)
+	tests := []struct {
+		name   string
+		frames []string
+		error  string
+	}{
+		{"too few legacy fields", []string{"1,2,3"}, "expects 17 fields, the frame has 3"},
+		{"too many fields", []string{"#schema,905,timestamp,altitude", "1,2,3"}, "expects 2 fields, the frame has 3"},
+		{"unknown version", []string{"#schema,999"}, "unknown schema version 999"},
+		{"invalid version", []string{"#schema,one,altitude"}, "invalid schema version"},
+		{"unknown unit", []string{"#schema,906,altitude:furlong"}, "can't convert furlong"},
+		{"unknown timestamp unit", []string{"#schema,906,timestamp:h"}, "unsupported timestamp unit"},
+		{"duplicate field", []string{"#schema,906,altitude,altitude"}, "twice"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseFrames(t, test.frames...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), test.error) {
+				t.Errorf("error %q doesn't contain %q", err, test.error)
+			}
+		})
+	}
+}
+
+func TestRegisterSchema(t *testing.T) {
+	forgetSchema(t, 907)
+	schema := TelemetrySchema{Version: 907, Fields: []SchemaField{{Name: "timestamp"}, {Name: "altitude", Unit: "m"}}}
+	if err := registerSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := registerSchema(schema); err != nil {
+		t.Errorf("registering the same schema again failed: %v", err)
+	}
+
+	conflicting := TelemetrySchema{Version: 907, Fields: []SchemaField{{Name: "timestamp"}, {Name: "altitude", Unit: "ft"}}}
+	if err := registerSchema(conflicting); err == nil {
+		t.Error("expected registering different fields under the same version to fail")
+	}
+	if registered, _ := lookupSchema(907); registered.Fields[1].Unit != "m" {
+		t.Errorf("the conflicting schema replaced the registered one: %v", registered)
+	}
+	if err := registerSchema(TelemetrySchema{Version: 1, Fields: []SchemaField{{Name: "altitude"}}}); err == nil {
+		t.Error("expected the legacy schema to be protected")
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"FlightControl/ThreeDView/object"
+	"FlightControl/ThreeDView/types"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"image/color"
+	"math"
+	"sync"
+)
+
+const (
+	trailWidth        = types.Unit(4)
+	trailMinSpacing   = types.Unit(20)
+	trailMaxSamples   = 1000
+	trailSpeedScale   = 40.0 // Speed in m/s that is rendered with the hottest color
+	trailApogeeMargin = 0.5  // Meters the rocket has to descend below the highest sample before the apogee is marked
+)
+
+type TrailColorMode int
+
+const (
+	TrailColorByPhase TrailColorMode = iota
+	TrailColorBySpeed
+)
+
+type trailSample struct {
+	position types.Point3D
+	data     Data
+}
+
+// FlightTrail renders the path flown by the rocket, its shadow on the ground and markers for the launch pad,
+// apogee and parachute deployment
+type FlightTrail struct {
+	line           *object.Polyline
+	groundTrack    *object.Polyline
+	launchLabel    *object.Label
+	apogeeLabel    *object.Label
+	parachuteLabel *object.Label
+	origin         types.Point3D // The launch site. Samples are placed relative to it
+	samples        []trailSample
+	apogee         trailSample
+	colorMode      TrailColorMode
+	mu             sync.Mutex
+}
+
+func NewFlightTrail(origin types.Point3D, w object.ThreeDWidgetInterface) *FlightTrail {
+	trail := &FlightTrail{
+		line:           object.NewPolyline(types.Point3D{}, trailWidth, w),
+		groundTrack:    object.NewPolyline(types.Point3D{}, trailWidth*2, w),
+		launchLabel:    object.NewLabel("Launch pad", origin, color.White, w),
+		apogeeLabel:    object.NewLabel("", types.Point3D{}, phaseColor(StatusUnpoweredAscent), w),
+		parachuteLabel: object.NewLabel("Parachute", types.Point3D{}, phaseColor(StatusParachuteDescent), w),
+		origin:         origin,
+		colorMode:      TrailColorByPhase,
+	}
+	trail.resetMarkers()
+	return trail
+}
+
+// AddSample adds a telemetry sample to the trail. Samples closer than trailMinSpacing to the previous one are skipped
+func (trail *FlightTrail) AddSample(data Data) {
+	trail.addSample(trailSample{position: trail.samplePosition(data), data: data})
+}
+
+// AddPosition adds a sample at an explicit world position, e.g. from a simulated flight
+func (trail *FlightTrail) AddPosition(position types.Point3D, data Data) {
+	trail.addSample(trailSample{position: position, data: data})
+}
+
+// LoadLog replaces the trail with the samples of a recorded log
+func (trail *FlightTrail) LoadLog(log Log) {
+	trail.mu.Lock()
+	trail.samples = nil
+	trail.resetMarkers()
+	for _, data := range log {
+		sample := trailSample{position: trail.samplePosition(data), data: data}
+		trail.updateMarkers(sample)
+		if trail.shouldSkip(sample) {
+			continue
+		}
+		trail.samples = append(trail.samples, sample)
+	}
+	trail.thin()
+	trail.mu.Unlock()
+	trail.rebuild()
+}
+
+func (trail *FlightTrail) Clear() {
+	trail.mu.Lock()
+	trail.samples = nil
+	trail.resetMarkers()
+	trail.mu.Unlock()
+	trail.rebuild()
+}
+
+func (trail *FlightTrail) SetColorMode(mode TrailColorMode) {
+	trail.mu.Lock()
+	trail.colorMode = mode
+	trail.mu.Unlock()
+	trail.rebuild()
+}
+
+// NearestSample returns the sample that is shown closest to a position on the screen, if it is within maxDistance.
+// project converts a point in world space to a position on the screen
+func (trail *FlightTrail) NearestSample(position fyne.Position, maxDistance float32, project func(types.Point3D) (fyne.Position, bool)) (Data, types.Point3D, bool) {
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	var nearest *trailSample
+	nearestDistance := maxDistance * maxDistance
+	for i := range trail.samples {
+		screenPosition, ok := project(trail.samples[i].position)
+		if !ok {
+			continue
+		}
+		dx, dy := screenPosition.X-position.X, screenPosition.Y-position.Y
+		if distance := dx*dx + dy*dy; distance <= nearestDistance {
+			nearest = &trail.samples[i]
+			nearestDistance = distance
+		}
+	}
+	if nearest == nil {
+		return Data{}, types.Point3D{}, false
+	}
+	return nearest.data, nearest.position, true
+}
+
+func (trail *FlightTrail) samplePosition(data Data) types.Point3D {
+	position := trail.origin
+	position.Add(types.Point3D{
+		X: types.Unit(data.xPosition) * altitudeScale,
+		Y: types.Unit(data.yPosition) * altitudeScale,
+		Z: types.Unit(data.altitude) * altitudeScale,
+	})
+	return position
+}
+
+func (trail *FlightTrail) addSample(sample trailSample) {
+	trail.mu.Lock()
+	trail.updateMarkers(sample)
+	if trail.shouldSkip(sample) {
+		trail.mu.Unlock()
+		return
+	}
+	trail.samples = append(trail.samples, sample)
+	if len(trail.samples) > trailMaxSamples {
+		trail.thin()
+		trail.mu.Unlock()
+		trail.rebuild()
+		return
+	}
+	trail.line.AddPoint(sample.position, trail.sampleColor(sample.data))
+	trail.addGroundPoint(sample.position)
+	trail.mu.Unlock()
+}
+
+func (trail *FlightTrail) resetMarkers() {
+	trail.apogee = trailSample{}
+	trail.apogeeLabel.SetHidden(true)
+	trail.parachuteLabel.SetHidden(true)
+}
+
+func (trail *FlightTrail) updateMarkers(sample trailSample) {
+	if sample.data.altitude > trail.apogee.data.altitude {
+		trail.apogee = sample
+		trail.apogeeLabel.SetPosition(sample.position)
+		trail.apogeeLabel.SetText(fmt.Sprintf("Apogee %.1f m", sample.data.altitude))
+		trail.apogeeLabel.SetHidden(true)
+	} else if sample.data.altitude < trail.apogee.data.altitude-trailApogeeMargin {
+		// Only mark the apogee once the rocket is clearly descending, otherwise the marker just follows the rocket
+		trail.apogeeLabel.SetHidden(false)
+	}
+	if sample.data.status == StatusParachuteDescent && trail.parachuteLabel.IsHidden() {
+		trail.parachuteLabel.SetPosition(sample.position)
+		trail.parachuteLabel.SetHidden(false)
+	}
+}
+
+func (trail *FlightTrail) shouldSkip(sample trailSample) bool {
+	if len(trail.samples) == 0 {
+		return false
+	}
+	last := trail.samples[len(trail.samples)-1]
+	return last.position.DistanceTo(sample.position) < trailMinSpacing && last.data.status == sample.data.status
+}
+
+// thin drops every second sample to keep the number of faces bounded on long flights
+func (trail *FlightTrail) thin() {
+	if len(trail.samples) <= trailMaxSamples {
+		return
+	}
+	thinned := make([]trailSample, 0, len(trail.samples)/2+1)
+	for i, sample := range trail.samples {
+		if i%2 == 0 || i == len(trail.samples)-1 {
+			thinned = append(thinned, sample)
+		}
+	}
+	trail.samples = thinned
+}
+
+func (trail *FlightTrail) rebuild() {
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	points := make([]types.Point3D, len(trail.samples))
+	colors := make([]color.Color, len(trail.samples))
+	for i, sample := range trail.samples {
+		points[i] = sample.position
+		colors[i] = trail.sampleColor(sample.data)
+	}
+	trail.line.SetPoints(points, colors)
+	trail.groundTrack.Clear()
+	for _, point := range points {
+		trail.addGroundPoint(point)
+	}
+}
+
+func (trail *FlightTrail) addGroundPoint(position types.Point3D) {
+	groundPoint := types.Point3D{X: position.X, Y: position.Y, Z: trail.origin.Z + 1}
+	if last, ok := trail.groundTrack.GetLastPoint(); ok && last.DistanceTo(groundPoint) < trailMinSpacing {
+		return
+	}
+	trail.groundTrack.AddPoint(groundPoint, color.RGBA{R: 0, G: 90, B: 0, A: 255})
+}
+
+func (trail *FlightTrail) sampleColor(data Data) color.Color {
+	if trail.colorMode == TrailColorBySpeed {
+		return speedColor(data.speed())
+	}
+	return phaseColor(data.status)
+}
+
+func phaseColor(status Status) color.Color {
+	switch status {
+	case StatusIdle:
+		return color.RGBA{R: 180, G: 180, B: 180, A: 255}
+	case StatusArmed:
+		return color.RGBA{R: 255, G: 220, B: 0, A: 255}
+	case StatusBoostedAscent:
+		return color.RGBA{R: 255, G: 60, B: 0, A: 255}
+	case StatusPoweredAscent:
+		return color.RGBA{R: 255, G: 140, B: 0, A: 255}
+	case StatusUnpoweredAscent:
+		return color.RGBA{R: 255, G: 200, B: 120, A: 255}
+	case StatusDescent:
+		return color.RGBA{R: 30, G: 110, B: 255, A: 255}
+	case StatusParachuteDescent:
+		return color.RGBA{R: 0, G: 220, B: 220, A: 255}
+	case StatusLanded:
+		return color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	default:
+		return color.RGBA{R: 255, G: 0, B: 255, A: 255}
+	}
+}
+
+// speedColor maps a speed to a color going from blue (standing still) to red (trailSpeedScale and faster)
+func speedColor(speed float64) color.Color {
+	t := math.Min(math.Abs(speed)/trailSpeedScale, 1)
+	return color.RGBA{R: uint8(255 * t), G: uint8(80 * (1 - math.Abs(2*t-1))), B: uint8(255 * (1 - t)), A: 255}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
-	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -93,6 +96,7 @@ func toLoggingStatus(statusString string) LoggingStatus {
 }
 
 type Data struct {
+	source         string
 	timestamp      string
 	altitude       float64
 	maxAltitude    float64
@@ -110,48 +114,47 @@ type Data struct {
 	xVelocity      float64
 	yVelocity      float64
 	zVelocity      float64
+	xPosition      float64       // Horizontal distance from the launch site in m, integrated from the velocity
+	yPosition      float64       // Horizontal distance from the launch site in m, integrated from the velocity
+	rocketTime     time.Duration // The timestamp on the rocket's clock, see TelemetryParser.RocketTime
+	hasRocketTime  bool
+	replayTime     time.Duration // Time since the start of the recording, only set for replayed samples
+	quality        QualityFlags
+	warnings       []string // Describe the problems flagged in quality
 }
 
-func parseCSVData(csvString string, dataVar *Data) {
-	reader := csv.NewReader(strings.NewReader(csvString))
-	record, err := reader.Read()
-	if err != nil {
-		println(err)
-		return
-	}
+func (data Data) speed() float64 {
+	return math.Sqrt(data.xVelocity*data.xVelocity + data.yVelocity*data.yVelocity + data.zVelocity*data.zVelocity)
+}
 
-	if len(record) == 17 {
-		dataVar.timestamp = record[0]
-		dataVar.altitude, _ = strconv.ParseFloat(record[1], 64)
-		dataVar.maxAltitude, _ = strconv.ParseFloat(record[2], 64)
-		dataVar.status = toStatus(record[3])
-		dataVar.voltage, _ = strconv.ParseFloat(record[4], 64)
-		dataVar.xRotation, _ = strconv.ParseFloat(record[5], 64)
-		dataVar.yRotation, _ = strconv.ParseFloat(record[6], 64)
-		dataVar.zRotation, _ = strconv.ParseFloat(record[7], 64)
-		dataVar.xRotationSpeed, _ = strconv.ParseFloat(record[8], 64)
-		dataVar.yRotationSpeed, _ = strconv.ParseFloat(record[9], 64)
-		dataVar.zRotationSpeed, _ = strconv.ParseFloat(record[10], 64)
-		dataVar.xAcceleration, _ = strconv.ParseFloat(record[11], 64)
-		dataVar.yAcceleration, _ = strconv.ParseFloat(record[12], 64)
-		dataVar.zAcceleration, _ = strconv.ParseFloat(record[13], 64)
-		dataVar.xVelocity, _ = strconv.ParseFloat(record[14], 64)
-		dataVar.yVelocity, _ = strconv.ParseFloat(record[15], 64)
-		dataVar.zVelocity, _ = strconv.ParseFloat(record[16], 64)
-	} else {
-		println("Invalid number of fields")
-	}
+func (data Data) describe() string {
+	return fmt.Sprintf("Time: %s  Status: %s  Altitude: %.2f m  Speed: %.2f m/s  Acceleration: %.2f/%.2f/%.2f m/s²  Rotation: %.1f/%.1f/%.1f°  Voltage: %.2f V",
+		data.timestamp, data.status, data.altitude, data.speed(),
+		data.xAcceleration, data.yAcceleration, data.zAcceleration,
+		data.xRotation, data.yRotation, data.zRotation, data.voltage)
 }
 
 type Log []Data
 
-func parseCSVLog(csvString string) Log {
-	lines := strings.Split(csvString, "\n")
-	log := make(Log, len(lines))
-	for i, line := range lines {
-		parseCSVData(line, &log[i])
+// parseCSVLog parses a log with one frame per line. Frames that can't be parsed are skipped and reported in the error
+func parseCSVLog(csvString string) (Log, error) {
+	parser := newTelemetryParser()
+	var log Log
+	var errs []error
+	for i, line := range strings.Split(csvString, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		data, ok, err := parser.Parse(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		if ok {
+			log = append(log, data)
+		}
 	}
-	return log
+	return log, errors.Join(errs...)
 }
 
 type LogListEntry struct {
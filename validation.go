@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const standardGravity = 9.80665 // m/s²
+
+// QualityFlags describe the problems the validation found in a sample
+type QualityFlags uint8
+
+const (
+	QualityUnparseable         QualityFlags = 1 << iota // A field couldn't be parsed
+	QualityOutOfRange                                   // A value is outside of what the sensor can measure
+	QualityStatusTransition                             // The status changed in a way the flight computer can't
+	QualityTimestampRegression                          // The timestamp is older than the one of the previous sample
+)
+
+var qualityFlagNames = []struct {
+	flag QualityFlags
+	name string
+}{
+	{QualityUnparseable, "unparseable"},
+	{QualityOutOfRange, "out of range"},
+	{QualityStatusTransition, "status transition"},
+	{QualityTimestampRegression, "timestamp regression"},
+}
+
+type valueRange struct {
+	min float64
+	max float64
+}
+
+// sensorRanges are the values the sensors of the flight computer can measure, in the units of legacySchema
+var sensorRanges = map[string]valueRange{
+	"voltage":          {0, 60},
+	"x-acceleration":   {-16 * standardGravity, 16 * standardGravity},
+	"y-acceleration":   {-16 * standardGravity, 16 * standardGravity},
+	"z-acceleration":   {-16 * standardGravity, 16 * standardGravity},
+	"x-rotation-speed": {-2000, 2000},
+	"y-rotation-speed": {-2000, 2000},
+	"z-rotation-speed": {-2000, 2000},
+}
+
+func (flags QualityFlags) String() string {
+	var names []string
+	for _, flag := range qualityFlagNames {
+		if flags&flag.flag != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (data *Data) flag(flag QualityFlags, warning string) {
+	data.quality |= flag
+	data.warnings = append(data.warnings, warning)
+}
+
+// validTransition reports whether the flight computer can change its status from one to the other. The flight phases
+// only advance, except for returning to idle when disarming or resetting. Any status can change to and from error
+func validTransition(from, to Status) bool {
+	return from == to || from == StatusError || to == StatusError || to == StatusIdle || to.toIndex() > from.toIndex()
+}
+
+// telemetryValidator checks the samples of one connection or file against the sensor ranges and the previous sample.
+// Fields that can't be parsed or are out of range are replaced by their last valid value, so they don't show up in the
+// plots
+type telemetryValidator struct {
+	last        Data
+	hasLast     bool
+	lastTime    time.Duration
+	hasLastTime bool
+}
+
+// validate flags the problems of data, whose fields in invalid couldn't be parsed. rocketTime is the time of the
+// timestamp of data. It returns the time of the timestamp data is left with, which is the last one if it was invalid
+func (validator *telemetryValidator) validate(data *Data, invalid map[string]bool, rocketTime time.Duration, hasTime bool) (time.Duration, bool) {
+	last := validator.last
+	if !validator.hasLast {
+		last = defaultTelemetry
+	}
+
+	for _, field := range legacySchema.Fields {
+		name := field.Name
+		value, ok := telemetryFields[name]
+		if !ok {
+			continue
+		}
+		if invalid[name] {
+			*value(data) = *value(&last)
+			continue
+		}
+		parsed := *value(data)
+		valid, limited := sensorRanges[name]
+		if math.IsNaN(parsed) || math.IsInf(parsed, 0) || limited && (parsed < valid.min || parsed > valid.max) {
+			data.flag(QualityOutOfRange, fmt.Sprintf("%s out of range: %g", name, parsed))
+			*value(data) = *value(&last)
+		}
+	}
+
+	if invalid["status"] {
+		data.status = last.status
+	} else if validator.hasLast && !validTransition(last.status, data.status) {
+		data.flag(QualityStatusTransition, fmt.Sprintf("impossible status transition from %s to %s", last.status, data.status))
+	}
+
+	if invalid["timestamp"] {
+		data.timestamp = last.timestamp
+		rocketTime, hasTime = last.rocketTime, last.hasRocketTime
+	} else if hasTime {
+		if validator.hasLastTime && rocketTime < validator.lastTime {
+			data.flag(QualityTimestampRegression, fmt.Sprintf("timestamp went back by %s", validator.lastTime-rocketTime))
+		}
+		validator.lastTime = rocketTime
+		validator.hasLastTime = true
+	}
+
+	validator.last = *data
+	validator.last.rocketTime, validator.last.hasRocketTime = rocketTime, hasTime
+	validator.hasLast = true
+	return rocketTime, hasTime
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// legacyFrame returns a valid legacy frame with the given fields replaced
+func legacyFrame(fields map[string]string) string {
+	values := []string{"1", "10", "10", "3", "7.4", "0", "0", "0", "0", "0", "0", "0", "0", "9.8", "0", "0", "5"}
+	for i, field := range legacySchema.Fields {
+		if value, ok := fields[field.Name]; ok {
+			values[i] = value
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func TestValidTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		valid    bool
+	}{
+		{StatusIdle, StatusPoweredAscent, true},
+		{StatusPoweredAscent, StatusUnpoweredAscent, true},
+		{StatusUnpoweredAscent, StatusDescent, true},
+		{StatusDescent, StatusParachuteDescent, true},
+		{StatusParachuteDescent, StatusLanded, true},
+		{StatusPoweredAscent, StatusLanded, true},
+		{StatusLanded, StatusLanded, true},
+		{StatusLanded, StatusIdle, true},
+		{StatusDescent, StatusError, true},
+		{StatusError, StatusPoweredAscent, true},
+		{StatusDescent, StatusPoweredAscent, false},
+		{StatusLanded, StatusParachuteDescent, false},
+		{StatusUnpoweredAscent, StatusPoweredAscent, false},
+	}
+	for _, test := range tests {
+		if valid := validTransition(test.from, test.to); valid != test.valid {
+			t.Errorf("validTransition(%s, %s) = %v, expected %v", test.from, test.to, valid, test.valid)
+		}
+	}
+}
+
+func TestValidateSamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous map[string]string
+		fields   map[string]string
+		flags    QualityFlags
+		check    func(Data) bool
+	}{
+		{
+			"valid sample",
+			map[string]string{"timestamp": "1"}, map[string]string{"timestamp": "2", "voltage": "7.2", "status": "4"},
+			0,
+			func(data Data) bool { return data.voltage == 7.2 && data.status == StatusUnpoweredAscent },
+		},
+		{
+			"unparseable field keeps the last valid value",
+			map[string]string{"timestamp": "1", "altitude": "42"}, map[string]string{"timestamp": "2", "altitude": "4x2"},
+			QualityUnparseable,
+			func(data Data) bool { return data.altitude == 42 },
+		},
+		{
+			"unparseable status keeps the last status",
+			map[string]string{"timestamp": "1", "status": "5"}, map[string]string{"timestamp": "2", "status": "descent"},
+			QualityUnparseable,
+			func(data Data) bool { return data.status == StatusDescent },
+		},
+		{
+			"voltage out of range",
+			map[string]string{"timestamp": "1", "voltage": "7.4"}, map[string]string{"timestamp": "2", "voltage": "61"},
+			QualityOutOfRange,
+			func(data Data) bool { return data.voltage == 7.4 },
+		},
+		{
+			"acceleration out of range",
+			map[string]string{"timestamp": "1", "z-acceleration": "20"}, map[string]string{"timestamp": "2", "z-acceleration": "-200"},
+			QualityOutOfRange,
+			func(data Data) bool { return data.zAcceleration == 20 },
+		},
+		{
+			"NaN",
+			map[string]string{"timestamp": "1", "altitude": "42"}, map[string]string{"timestamp": "2", "altitude": "NaN"},
+			QualityOutOfRange,
+			func(data Data) bool { return data.altitude == 42 },
+		},
+		{
+			"infinity",
+			map[string]string{"timestamp": "1", "x-rotation": "10"}, map[string]string{"timestamp": "2", "x-rotation": "+Inf"},
+			QualityOutOfRange,
+			func(data Data) bool { return data.xRotation == 10 },
+		},
+		{
+			"impossible status transition",
+			map[string]string{"timestamp": "1", "status": "5"}, map[string]string{"timestamp": "2", "status": "3"},
+			QualityStatusTransition,
+			func(data Data) bool { return data.status == StatusPoweredAscent },
+		},
+		{
+			"timestamp regression",
+			map[string]string{"timestamp": "5"}, map[string]string{"timestamp": "4"},
+			QualityTimestampRegression,
+			func(data Data) bool { return data.timestamp == "4" && data.rocketTime == 4*time.Second },
+		},
+		{
+			"unparseable timestamp keeps the last timestamp",
+			map[string]string{"timestamp": "5"}, map[string]string{"timestamp": "soon"},
+			QualityUnparseable,
+			func(data Data) bool {
+				return data.timestamp == "5" && data.rocketTime == 5*time.Second && data.hasRocketTime
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := newTelemetryParser()
+			if _, _, err := parser.Parse(legacyFrame(test.previous)); err != nil {
+				t.Fatal(err)
+			}
+			data, _, err := parser.Parse(legacyFrame(test.fields))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.quality != test.flags {
+				t.Errorf("flags %q, expected %q (%v)", data.quality, test.flags, data.warnings)
+			}
+			if test.flags != 0 && len(data.warnings) == 0 {
+				t.Error("expected a warning")
+			}
+			if !test.check(data) {
+				t.Errorf("unexpected data %+v", data)
+			}
+		})
+	}
+}
+
+func TestValidateFirstSample(t *testing.T) {
+	// Without a previous sample invalid values fall back to the defaults and no transition is checked
+	data, _, err := newTelemetryParser().Parse(legacyFrame(map[string]string{"status": "6", "voltage": "-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.quality != QualityOutOfRange || data.voltage != defaultTelemetry.voltage {
+		t.Errorf("flags %q and voltage %v", data.quality, data.voltage)
+	}
+	if data.status != StatusParachuteDescent {
+		t.Errorf("status %s", data.status)
+	}
+}